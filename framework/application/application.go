@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/3n0ugh/allotropes/framework/swagger"
 	"github.com/3n0ugh/allotropes/internal/errors"
@@ -32,6 +33,15 @@ func (a *App) Run() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, c := range a.Controllers {
+		for _, j := range c.Jobs {
+			go a.runJob(ctx, j)
+		}
+	}
+
 	go func() {
 		if err := a.server.ListenAndServe(); err != nil {
 			log.Printf("Error running server: %s", err)
@@ -46,14 +56,30 @@ func (a *App) Run() {
 	}
 }
 
+func (a *App) runJob(ctx context.Context, j Job) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.Run(ctx); err != nil {
+				log.Printf("job %s: %s", j.Name, err)
+			}
+		}
+	}
+}
+
 func (a *App) Setup() {
 	router := chi.NewRouter()
 
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Match", "If-None-Match", "X-Change-Reason"},
+		ExposedHeaders:   []string{"Link", "ETag"},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
@@ -63,7 +89,7 @@ func (a *App) Setup() {
 
 	for _, c := range a.Controllers {
 		for _, r := range c.Routes {
-			router.Method(r.Method, r.Path, r.Handler)
+			router.With(r.Middlewares...).Method(r.Method, r.Path, r.Handler)
 		}
 	}
 
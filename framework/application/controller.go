@@ -1,15 +1,20 @@
 package application
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
 )
 
 type Controller struct {
 	Name        string
 	Description string
 	Routes      []Route
+	Jobs        []Job
 }
 
 type Route struct {
@@ -32,6 +37,13 @@ func (r Route) GetMiddlewares() []func(http.Handler) http.Handler { return r.Mid
 func (r Route) GetHeaders() map[string]string                     { return r.Headers }
 func (r Route) GetPath() string                                   { return r.Path }
 
+// Job is a background task the application runs every Interval until it shuts down.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
 type HandlerFunc func(w http.ResponseWriter, r *http.Request) (response any, err error)
 
 func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -41,6 +53,14 @@ func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	res, err := h(w, r)
 	if err != nil {
 		log.Printf("err: %s", err.Error())
+
+		e := errors.Translate(err)
+		w.WriteHeader(e.StatusCode)
+		res = e
+	}
+
+	if res == nil {
+		return
 	}
 
 	err = json.NewEncoder(w).Encode(res)
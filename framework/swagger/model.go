@@ -79,7 +79,9 @@ type Content struct {
 }
 
 type ContentSchema struct {
-	Ref string `yaml:"$ref,omitempty"`
+	Ref   string `yaml:"$ref,omitempty"`
+	Type  string `yaml:"type,omitempty"`
+	Items *Items `yaml:"items,omitempty"`
 }
 
 type Response struct {
@@ -131,6 +133,8 @@ type Property struct {
 	Enum        []any  `yaml:"enum,omitempty"`
 	Ref         string `yaml:"$ref,omitempty"`
 	Items       Items  `yaml:"items,omitempty"`
+	// AdditionalProperties describes the values of maps, documented as objects.
+	AdditionalProperties *Items `yaml:"additionalProperties,omitempty"`
 }
 
 type Items struct {
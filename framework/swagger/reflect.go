@@ -16,10 +16,22 @@ const (
 var (
 	validParameterTags = []string{"header", "query", "path"}
 	validBodyTags      = []string{"json"}
-	validMethods       = []string{"get", "put", "post", "head", "delete", "trace", "options", "path"}
+	validMethods       = []string{"get", "put", "post", "head", "delete", "trace", "options", "patch"}
 	authMiddlewares    = []string{"auth"}
 )
 
+// conditionalResponses are the responses an operation may additionally return
+// when it accepts the conditional request header.
+var conditionalResponses = map[string]map[string]string{
+	"If-Match": {
+		"412": "Precondition Failed",
+		"428": "Precondition Required",
+	},
+	"If-None-Match": {
+		"304": "Not Modified",
+	},
+}
+
 var Types = map[string]HeaderSchema{
 	"string": {Type: "string", Format: ""},
 
@@ -88,6 +100,7 @@ func (s *Swagger) SetPaths(r Route, tag string) {
 	operation.SetRequestBody(reflect.TypeOf(r.GetRequestModel()))
 	operation.SetParameters(reflect.TypeOf(r.GetRequestModel()))
 	operation.SetResponses(reflect.TypeOf(r.GetResponseModel()), r.GetHeaders())
+	operation.SetConditionalResponses()
 
 	p := s.Paths[r.GetPath()]
 	switch r.GetMethod() {
@@ -121,6 +134,12 @@ func (o *Operation) SetSecurity(middlewares []func(http.Handler) http.Handler) {
 	}
 }
 
+// MediaTyper is implemented by request models whose body is accepted in other media types
+// than application/json. Each media type maps to a value of the model of its body.
+type MediaTyper interface {
+	MediaTypes() map[string]any
+}
+
 func (o *Operation) SetRequestBody(val reflect.Type) {
 	obj := val
 	if obj.Kind() == reflect.Ptr {
@@ -138,6 +157,14 @@ func (o *Operation) SetRequestBody(val reflect.Type) {
 		return
 	}
 
+	if m, ok := reflect.Zero(val).Interface().(MediaTyper); ok {
+		o.RequestBody = RequestBody{Content: map[string]Content{}}
+		for mediaType, model := range m.MediaTypes() {
+			o.RequestBody.Content[mediaType] = Content{Schema: S.contentSchema(reflect.TypeOf(model))}
+		}
+		return
+	}
+
 	o.RequestBody = RequestBody{
 		Content: map[string]Content{
 			"application/json": {
@@ -151,6 +178,17 @@ func (o *Operation) SetRequestBody(val reflect.Type) {
 	S.SetSchema(val)
 }
 
+// contentSchema returns the schema of a struct or of a slice of structs, registering the struct.
+func (s *Swagger) contentSchema(val reflect.Type) ContentSchema {
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		s.SetSchema(val.Elem())
+		return ContentSchema{Type: "array", Items: &Items{Ref: ReferencePrefix + val.Elem().Name()}}
+	}
+
+	s.SetSchema(val)
+	return ContentSchema{Ref: ReferencePrefix + val.Name()}
+}
+
 func (o *Operation) SetParameters(val reflect.Type) {
 	obj := val
 	if obj.Kind() == reflect.Ptr {
@@ -177,6 +215,9 @@ func (o *Operation) SetParameters(val reflect.Type) {
 
 		switch field.Type.Kind() {
 		case reflect.Struct, reflect.Map:
+			if field.Type.String() == "time.Time" {
+				o.Parameters = append(o.Parameters, p)
+			}
 		case reflect.Slice, reflect.Array:
 			p.Explode = true
 			p.Schema = Types[field.Type.Elem().String()]
@@ -219,6 +260,18 @@ func (o *Operation) SetResponses(val reflect.Type, headers map[string]string) {
 	S.SetSchema(val)
 }
 
+func (o *Operation) SetConditionalResponses() {
+	for _, p := range o.Parameters {
+		if p.In != "header" {
+			continue
+		}
+
+		for code, desc := range conditionalResponses[p.Name] {
+			o.Responses[code] = Response{Description: desc}
+		}
+	}
+}
+
 func (s *Swagger) SetSchema(val reflect.Type) {
 	o := val
 	if o.Kind() == reflect.Ptr {
@@ -232,31 +285,43 @@ func (s *Swagger) SetSchema(val reflect.Type) {
 			continue
 		}
 
-		if field.Type.Kind() == reflect.Struct && field.Type.String() != "time.Time" {
-			s.SetSchema(field.Type)
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if ft.Kind() == reflect.Struct && ft.String() != "time.Time" {
+			s.SetSchema(ft)
 
 			properties[tag] = Property{
-				Ref: ReferencePrefix + field.Type.Name(),
+				Ref: ReferencePrefix + ft.Name(),
 			}
-		} else if field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array {
-			if field.Type.Elem().Kind() == reflect.Struct {
+		} else if ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
+			if ft.Elem().Kind() == reflect.Struct {
 				properties[tag] = Property{
 					Type:  "array",
-					Items: Items{Ref: ReferencePrefix + field.Type.Elem().Name()},
+					Items: Items{Ref: ReferencePrefix + ft.Elem().Name()},
 				}
-				s.SetSchema(field.Type.Elem())
+				s.SetSchema(ft.Elem())
 			} else {
 				properties[tag] = Property{
 					Type: "array",
 					Items: Items{
-						Type: Types[field.Type.Elem().Kind().String()].Type,
+						Type: Types[ft.Elem().Kind().String()].Type,
 					},
 				}
 			}
-		} else if field.Type.Kind() != reflect.Map {
+		} else if ft.Kind() == reflect.Map {
+			values := &Items{Type: Types[ft.Elem().Kind().String()].Type}
+			if ft.Elem().Kind() == reflect.Struct && ft.Elem().String() != "time.Time" {
+				values = &Items{Ref: ReferencePrefix + ft.Elem().Name()}
+				s.SetSchema(ft.Elem())
+			}
+			properties[tag] = Property{Type: "object", AdditionalProperties: values}
+		} else {
 			properties[tag] = Property{
-				Type:   Types[field.Type.String()].Type,
-				Format: Types[field.Type.String()].Format,
+				Type:   Types[ft.String()].Type,
+				Format: Types[ft.String()].Format,
 			}
 		}
 	}
@@ -281,7 +346,7 @@ func StringSliceContains(slice []string, s string) bool {
 
 func isValidTag(tags []string, tag reflect.StructTag) (string, string) {
 	for _, validTag := range tags {
-		if t := tag.Get(validTag); t != "" && t != "-" {
+		if t := strings.Split(tag.Get(validTag), ",")[0]; t != "" && t != "-" {
 			return t, validTag
 		}
 	}
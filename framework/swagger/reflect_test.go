@@ -224,13 +224,50 @@ func TestOperation_SetRequestBody(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+type mediaTypedBody struct {
+	Name string `json:"name"`
+}
+
+type mediaTypedRequest struct {
+	Body []byte `json:"body"`
+}
+
+func (mediaTypedRequest) MediaTypes() map[string]any {
+	return map[string]any{
+		"application/merge-patch+json": mediaTypedBody{},
+		"application/json-patch+json":  []mediaTypedBody{},
+	}
+}
+
+func TestOperation_SetRequestBody_ShouldDocumentMediaTypes(t *testing.T) {
+	expected := Operation{
+		RequestBody: RequestBody{
+			Content: map[string]Content{
+				"application/merge-patch+json": {
+					Schema: ContentSchema{Ref: ReferencePrefix + "mediaTypedBody"},
+				},
+				"application/json-patch+json": {
+					Schema: ContentSchema{Type: "array", Items: &Items{Ref: ReferencePrefix + "mediaTypedBody"}},
+				},
+			},
+		},
+	}
+
+	actual := Operation{}
+	actual.SetRequestBody(reflect.TypeOf(mediaTypedRequest{}))
+
+	assert.Equal(t, expected, actual)
+	assert.Contains(t, S.Components.Schemas, "mediaTypedBody")
+}
+
 func TestOperation_SetParameters(t *testing.T) {
 	type X struct {
-		Name    string   `query:"name" description:"abcdef" required:"true" deprecated:"true"`
-		Planes  []string `query:"planes"`
-		ID      float64  `path:"id"`
-		Test    int      `json:"test"`
-		boolean bool     `query:"boolean"`
+		Name    string    `query:"name" description:"abcdef" required:"true" deprecated:"true"`
+		Planes  []string  `query:"planes"`
+		ID      float64   `path:"id"`
+		Since   time.Time `query:"since"`
+		Test    int       `json:"test"`
+		boolean bool      `query:"boolean"`
 	}
 
 	expected := Operation{
@@ -261,6 +298,14 @@ func TestOperation_SetParameters(t *testing.T) {
 					Format: "double",
 				},
 			},
+			{
+				Name: "since",
+				In:   "query",
+				Schema: HeaderSchema{
+					Type:   "string",
+					Format: "date",
+				},
+			},
 		},
 	}
 
@@ -309,6 +354,30 @@ func TestOperation_SetResponses(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestOperation_SetConditionalResponses(t *testing.T) {
+	expected := Operation{
+		Parameters: []Parameter{
+			{Name: "If-Match", In: "header"},
+			{Name: "If-None-Match", In: "query"},
+		},
+		Responses: map[string]Response{
+			"412": {Description: "Precondition Failed"},
+			"428": {Description: "Precondition Required"},
+		},
+	}
+
+	actual := Operation{
+		Parameters: []Parameter{
+			{Name: "If-Match", In: "header"},
+			{Name: "If-None-Match", In: "query"},
+		},
+		Responses: map[string]Response{},
+	}
+	actual.SetConditionalResponses()
+
+	assert.Equal(t, expected, actual)
+}
+
 func TestSwagger_SetSchema(t *testing.T) {
 	type X struct {
 		B bool `json:"b"`
@@ -377,7 +446,22 @@ func TestSwagger_SetSchema(t *testing.T) {
 						"Model": {
 							XSwaggerRouterModel: RouterModelPrefix + "Model",
 							Properties: map[string]Property{
-								"rel": {
+								"page": {
+									Type:   "integer",
+									Format: "int32",
+								},
+								"size": {
+									Type:   "integer",
+									Format: "int32",
+								},
+								"totalPages": {
+									Type:   "integer",
+									Format: "int32",
+								},
+								"first": {
+									Type: "string",
+								},
+								"last": {
 									Type: "string",
 								},
 								"next": {
@@ -386,6 +470,45 @@ func TestSwagger_SetSchema(t *testing.T) {
 								"prev": {
 									Type: "string",
 								},
+								"nextCursor": {
+									Type: "string",
+								},
+							},
+							Type: "object",
+						},
+					},
+				},
+			},
+		},
+		"should add map schemas": {
+			Given: struct {
+				Labels map[string]string `json:"labels"`
+				ByName map[string]X      `json:"byName"`
+			}{},
+			Expected: Swagger{
+				Components: Component{
+					Schemas: map[string]ComponentSchema{
+						"": {
+							XSwaggerRouterModel: RouterModelPrefix,
+							Properties: map[string]Property{
+								"labels": {
+									Type:                 "object",
+									AdditionalProperties: &Items{Type: "string"},
+								},
+								"byName": {
+									Type:                 "object",
+									AdditionalProperties: &Items{Ref: ReferencePrefix + "X"},
+								},
+							},
+							Type: "object",
+						},
+						"X": {
+							XSwaggerRouterModel: RouterModelPrefix + "X",
+							Properties: map[string]Property{
+								"b": {
+									Type:   "boolean",
+									Format: "",
+								},
 							},
 							Type: "object",
 						},
@@ -0,0 +1,47 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+const (
+	SourceLocal = "local"
+	SourceS3    = "s3"
+)
+
+// Object is a stored blob. The caller closes its body.
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// Store keeps blobs by slash separated keys like movies/1/poster/abc/original.jpg.
+// Missing blobs are reported as errors.ErrNotFound.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix deletes every blob whose key starts with the prefix, which ends with a slash.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// checkKey rejects keys which would escape the store or address it ambiguously.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.Contains(key, "..") {
+		return errors.New("invalid blob key " + key)
+	}
+	return nil
+}
+
+func checkPrefix(prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return errors.New("blob prefix must end with a slash")
+	}
+	return checkKey(strings.TrimSuffix(prefix, "/"))
+}
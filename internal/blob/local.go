@@ -0,0 +1,106 @@
+package blob
+
+import (
+	"context"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// Local stores blobs as files under a root directory. The content type follows from the
+// extension of the key.
+type Local struct {
+	root string
+}
+
+func NewLocal(root string) *Local {
+	return &Local{root: root}
+}
+
+func (l *Local) path(key string) string {
+	return filepath.Join(l.root, filepath.FromSlash(key))
+}
+
+// Put writes the blob to a temporary file renamed into place, so that readers never see
+// a partial blob.
+func (l *Local) Put(_ context.Context, key string, data []byte, _ string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	p := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return errors.Wrap(err, "blob directory")
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "blob create")
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return errors.Wrap(err, "blob write")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "blob close")
+	}
+
+	if err := os.Rename(f.Name(), p); err != nil {
+		return errors.Wrap(err, "blob rename")
+	}
+	return nil
+}
+
+func (l *Local) Get(_ context.Context, key string) (*Object, error) {
+	if err := checkKey(key); err != nil {
+		return nil, errors.Wrap(errors.ErrNotFound, err.Error())
+	}
+
+	f, err := os.Open(l.path(key))
+	if os.IsNotExist(err) {
+		return nil, errors.Wrap(errors.ErrNotFound, "blob "+key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "blob open")
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "blob stat")
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, errors.Wrap(errors.ErrNotFound, "blob "+key)
+	}
+
+	return &Object{Body: f, ContentType: mime.TypeByExtension(path.Ext(key)), Size: info.Size()}, nil
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	err := os.Remove(l.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "blob remove")
+	}
+	return nil
+}
+
+func (l *Local) DeletePrefix(_ context.Context, prefix string) error {
+	if err := checkPrefix(prefix); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(l.path(prefix)); err != nil {
+		return errors.Wrap(err, "blob remove")
+	}
+	return nil
+}
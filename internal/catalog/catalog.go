@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"context"
+	"time"
+)
+
+// Movie is the summary of a movie shown by resources which reference movies, like watchlist entries.
+type Movie struct {
+	ID          int       `json:"ID"`
+	Title       string    `json:"Title"`
+	ReleaseDate time.Time `json:"ReleaseDate"`
+	Runtime     int       `json:"Runtime"`
+	Language    string    `json:"Language"`
+	Genres      []string  `json:"Genres"`
+}
+
+// Movies looks up movie summaries for resources outside the movie package.
+type Movies interface {
+	// Summaries returns the summaries of the movies found by id. Missing and soft deleted
+	// movies are left out.
+	Summaries(ctx context.Context, ids []int) (map[int]Movie, error)
+}
+
+// Collection references the collection a movie belongs to, with the place of the movie in it.
+type Collection struct {
+	ID       int    `json:"ID"`
+	Name     string `json:"Name"`
+	Position int    `json:"Position"`
+	Size     int    `json:"Size"`
+}
+
+// Collections looks up the collections of movies for the movie package, and keeps them
+// free of movies leaving the catalog.
+type Collections interface {
+	// OfMovie returns the collection of the movie, nil when it belongs to none.
+	OfMovie(ctx context.Context, movieID int) (*Collection, error)
+	// RemoveMovie takes the movie out of its collection, if it belongs to one.
+	RemoveMovie(ctx context.Context, movieID int) error
+}
@@ -1,30 +1,64 @@
 package config
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
 
 const (
 	secret            = "test"
+	requireIfMatch    = "false"
+	movieIDSource     = "couchbase"
+	movieRepository   = "couchbase"
+	searchBackend     = "postgresql"
+	trashRetention    = "720h"
 	postgresqlDSN     = "localdsn"
 	couchbaseDSN      = "localdsn"
 	couchbaseUsername = "localusername"
 	couchbasePassword = "cbpass"
 	couchbaseBucket   = "cbbucket"
+	blobStore         = "local"
+	blobRoot          = "./data/blobs"
+	imageBaseURL      = "/v1/images/"
+	s3Region          = "us-east-1"
 )
 
 type Config struct {
 	Application Application
 	PostgreSQL  PostgreSQL
 	Couchbase   Couchbase
+	Blob        Blob
 }
 
 type Application struct {
-	Secret string
+	Secret          string
+	RequireIfMatch  bool
+	MovieIDSource   string
+	MovieRepository string
+	SearchBackend   string
+	TrashRetention  time.Duration
 }
 
+// PostgreSQL is required whatever the movie sources are: watchlists and collections are
+// stored there.
 type PostgreSQL struct {
 	DataSource string
 }
 
+// Blob configures where uploaded images are stored, and the URL prefix they are served from.
+type Blob struct {
+	Store       string
+	Root        string
+	BaseURL     string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
 type Couchbase struct {
 	BucketName string
 	UserName   string
@@ -32,10 +66,16 @@ type Couchbase struct {
 	DataSource string
 }
 
-func ReadConfig() Config {
-	return Config{
+// ReadConfig reads the configuration from the environment and rejects unknown backends.
+func ReadConfig() (Config, error) {
+	c := Config{
 		Application: Application{
-			Secret: setConfig("SECRET", secret),
+			Secret:          setConfig("SECRET", secret),
+			RequireIfMatch:  setBoolConfig("REQUIRE_IF_MATCH", requireIfMatch),
+			MovieIDSource:   setConfig("MOVIE_ID_SOURCE", movieIDSource),
+			MovieRepository: setConfig("MOVIE_REPOSITORY", movieRepository),
+			SearchBackend:   setConfig("SEARCH_BACKEND", searchBackend),
+			TrashRetention:  setDurationConfig("TRASH_RETENTION", trashRetention),
 		},
 		PostgreSQL: PostgreSQL{
 			DataSource: setConfig("POSTGRES_DSN", postgresqlDSN),
@@ -46,7 +86,24 @@ func ReadConfig() Config {
 			Password:   setConfig("CB_PASSWORD", couchbasePassword),
 			DataSource: setConfig("CB_DSN", couchbaseDSN),
 		},
+		Blob: Blob{
+			Store:       setConfig("BLOB_STORE", blobStore),
+			Root:        setConfig("BLOB_ROOT", blobRoot),
+			BaseURL:     setConfig("IMAGE_BASE_URL", imageBaseURL),
+			S3Endpoint:  os.Getenv("S3_ENDPOINT"),
+			S3Region:    setConfig("S3_REGION", s3Region),
+			S3Bucket:    os.Getenv("S3_BUCKET"),
+			S3AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			S3SecretKey: os.Getenv("S3_SECRET_KEY"),
+		},
+	}
+
+	switch c.Application.MovieIDSource {
+	case "couchbase", "postgresql":
+	default:
+		return c, fmt.Errorf("MOVIE_ID_SOURCE must be couchbase or postgresql, got %q", c.Application.MovieIDSource)
 	}
+	return c, nil
 }
 
 func setConfig(configName, defaultVal string) string {
@@ -55,3 +112,16 @@ func setConfig(configName, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+func setBoolConfig(configName, defaultVal string) bool {
+	b, _ := strconv.ParseBool(setConfig(configName, defaultVal))
+	return b
+}
+
+func setDurationConfig(configName, defaultVal string) time.Duration {
+	d, err := time.ParseDuration(setConfig(configName, defaultVal))
+	if err != nil {
+		d, _ = time.ParseDuration(defaultVal)
+	}
+	return d
+}
@@ -0,0 +1,30 @@
+package country
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	testCases := map[string]struct {
+		Code     string
+		Expected bool
+	}{
+		"should accept assigned codes":   {Code: "DE", Expected: true},
+		"should accept the last code":    {Code: "ZW", Expected: true},
+		"should reject lower case codes": {Code: "de", Expected: false},
+		"should reject unassigned codes": {Code: "XX", Expected: false},
+		"should reject alpha-3 codes":    {Code: "DEU", Expected: false},
+		"should reject reserved codes":   {Code: "UK", Expected: false},
+		"should reject empty codes":      {Code: "", Expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, Valid(tc.Code))
+		})
+	}
+
+	assert.Len(t, codes, 249)
+}
@@ -0,0 +1,66 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// Encode returns an opaque token carrying v, signed with the secret so that clients
+// cannot forge or alter it.
+func Encode(secret string, v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "cursor marshal")
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(sign(secret, payload)), nil
+}
+
+// Decode verifies the token signature and unmarshals its payload into v.
+func Decode(secret, token string, v any) error {
+	enc := base64.RawURLEncoding
+
+	p, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return errors.New("malformed cursor")
+	}
+
+	payload, err := enc.DecodeString(p)
+	if err != nil {
+		return errors.Wrap(err, "cursor payload")
+	}
+
+	signature, err := enc.DecodeString(s)
+	if err != nil {
+		return errors.Wrap(err, "cursor signature")
+	}
+
+	if !hmac.Equal(signature, sign(secret, payload)) {
+		return errors.New("invalid cursor signature")
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return errors.Wrap(err, "cursor unmarshal")
+	}
+	return nil
+}
+
+func sign(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, signingKey(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// signingKey derives the signing key of the cursors from the secret, so that cursors never share
+// a key with the tokens signed by the same secret.
+func signingKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("cursor"))
+	return mac.Sum(nil)
+}
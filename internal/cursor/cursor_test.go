@@ -0,0 +1,41 @@
+package cursor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type key struct {
+	Values []any `json:"v"`
+	ID     int   `json:"i"`
+}
+
+func TestEncodeDecode(t *testing.T) {
+	token, err := Encode("secret", key{Values: []any{"2001-12-19T00:00:00Z"}, ID: 120})
+	assert.NoError(t, err)
+
+	var actual key
+	assert.NoError(t, Decode("secret", token, &actual))
+	assert.Equal(t, key{Values: []any{"2001-12-19T00:00:00Z"}, ID: 120}, actual)
+}
+
+func TestDecode_ShouldRejectTamperedToken(t *testing.T) {
+	token, err := Encode("secret", key{ID: 120})
+	assert.NoError(t, err)
+
+	forged, err := Encode("other", key{ID: 1})
+	assert.NoError(t, err)
+
+	p, _, _ := strings.Cut(forged, ".")
+	_, s, _ := strings.Cut(token, ".")
+
+	for _, tc := range []string{"", "abc", token + "x", p + "." + s} {
+		var actual key
+		assert.Error(t, Decode("secret", tc, &actual), tc)
+	}
+
+	var actual key
+	assert.Error(t, Decode("other", token, &actual))
+}
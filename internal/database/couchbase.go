@@ -8,7 +8,8 @@ import (
 	"github.com/couchbase/gocb/v2"
 )
 
-func OpenConnectionCB(cfg config.Config) (*gocb.Bucket, error) {
+// OpenConnectionCB returns the cluster, needed for transactions, and the configured bucket.
+func OpenConnectionCB(cfg config.Config) (*gocb.Cluster, *gocb.Bucket, error) {
 	cluster, err := gocb.Connect(cfg.Couchbase.DataSource, gocb.ClusterOptions{
 		Authenticator: gocb.PasswordAuthenticator{
 			Username: cfg.Couchbase.UserName,
@@ -16,14 +17,14 @@ func OpenConnectionCB(cfg config.Config) (*gocb.Bucket, error) {
 		},
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "couchbase connection")
+		return nil, nil, errors.Wrap(err, "couchbase connection")
 	}
 
 	bucket := cluster.Bucket(cfg.Couchbase.BucketName)
 	err = bucket.WaitUntilReady(5*time.Second, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "bucket connection")
+		return nil, nil, errors.Wrap(err, "bucket connection")
 	}
 
-	return bucket, nil
+	return cluster, bucket, nil
 }
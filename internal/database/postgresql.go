@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	_ "embed"
 	"time"
 
 	"github.com/3n0ugh/allotropes/internal/config"
@@ -10,6 +11,9 @@ import (
 	_ "github.com/lib/pq"
 )
 
+//go:embed schema.sql
+var schema string
+
 func OpenConnectionPQ(cfg config.Config) (*sql.DB, error) {
 	db, err := sql.Open("postgres", cfg.PostgreSQL.DataSource)
 	if err != nil {
@@ -24,5 +28,10 @@ func OpenConnectionPQ(cfg config.Config) (*sql.DB, error) {
 		return nil, errors.Wrap(err, "ping")
 	}
 
+	_, err = db.ExecContext(ctx, schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "schema")
+	}
+
 	return db, nil
 }
@@ -0,0 +1,62 @@
+CREATE SEQUENCE IF NOT EXISTS movie_id_seq;
+
+CREATE TABLE IF NOT EXISTS movie (
+    id  integer PRIMARY KEY,
+    doc jsonb   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS movie_doc_idx ON movie USING gin (doc jsonb_path_ops);
+
+ALTER TABLE movie ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(doc ->> 'Title', '')), 'A') ||
+    setweight(jsonb_to_tsvector('english', coalesce(doc -> 'Keywords', '[]'), '["string"]'), 'B') ||
+    setweight(jsonb_to_tsvector('english', jsonb_path_query_array(doc, '$.Cast[*].Name'), '["string"]'), 'B') ||
+    setweight(to_tsvector('english', coalesce(doc ->> 'ShortStory', '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(doc ->> 'Story', '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS movie_search_idx ON movie USING gin (search);
+
+CREATE SEQUENCE IF NOT EXISTS person_id_seq;
+
+CREATE SEQUENCE IF NOT EXISTS term_id_seq;
+
+CREATE SEQUENCE IF NOT EXISTS rewrite_id_seq;
+
+CREATE TABLE IF NOT EXISTS watchlist (
+    id         serial      PRIMARY KEY,
+    owner      text        NOT NULL,
+    name       text        NOT NULL,
+    is_default boolean     NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (owner, name)
+);
+
+CREATE TABLE IF NOT EXISTS watchlist_entry (
+    watchlist_id integer     NOT NULL REFERENCES watchlist (id) ON DELETE CASCADE,
+    movie_id     integer     NOT NULL,
+    position     integer     NOT NULL,
+    note         text        NOT NULL DEFAULT '',
+    added_at     timestamptz NOT NULL DEFAULT now(),
+    watched_at   timestamptz,
+    PRIMARY KEY (watchlist_id, movie_id),
+    UNIQUE (watchlist_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE TABLE IF NOT EXISTS collection (
+    id         serial      PRIMARY KEY,
+    name       text        NOT NULL,
+    overview   text        NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS collection_movie (
+    collection_id integer     NOT NULL REFERENCES collection (id) ON DELETE CASCADE,
+    movie_id      integer     NOT NULL UNIQUE,
+    position      integer     NOT NULL,
+    added_at      timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, movie_id),
+    UNIQUE (collection_id, position) DEFERRABLE INITIALLY DEFERRED
+);
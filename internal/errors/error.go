@@ -11,12 +11,28 @@ type Error struct {
 	Title      string `json:"title"`
 	Message    string `json:"message"`
 	devMessage string `json:"-"`
+	cause      error  `json:"-"`
 }
 
 func (e Error) Error() string {
 	return fmt.Sprintf("%s", e.devMessage)
 }
 
+// Unwrap returns the underlying error the Error was translated from, if any.
+func (e Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an *Error with the same status code, so that
+// errors.Is(err, ErrNotFound) matches every not found error regardless of its message.
+func (e Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.StatusCode == e.StatusCode
+}
+
 func New(str string) error {
 	return errors.New(str)
 }
@@ -24,3 +40,11 @@ func New(str string) error {
 func Wrap(err error, str string) error {
 	return errors.Wrap(err, str)
 }
+
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
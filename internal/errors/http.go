@@ -51,6 +51,69 @@ func NewUnAuthorizedError(message, devMessage string) *Error {
 	}
 }
 
+func NewConflictError(message, devMessage string) *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Title:      "conflict",
+		Message:    message,
+		devMessage: devMessage,
+	}
+}
+
+func NewPreconditionFailedError(message, devMessage string) *Error {
+	return &Error{
+		StatusCode: http.StatusPreconditionFailed,
+		Title:      "precondition failed",
+		Message:    message,
+		devMessage: devMessage,
+	}
+}
+
+func NewPreconditionRequiredError(message, devMessage string) *Error {
+	return &Error{
+		StatusCode: http.StatusPreconditionRequired,
+		Title:      "precondition required",
+		Message:    message,
+		devMessage: devMessage,
+	}
+}
+
+func NewFailedDependencyError(message, devMessage string) *Error {
+	return &Error{
+		StatusCode: http.StatusFailedDependency,
+		Title:      "failed dependency",
+		Message:    message,
+		devMessage: devMessage,
+	}
+}
+
+func NewUnsupportedMediaTypeError(message, devMessage string) *Error {
+	return &Error{
+		StatusCode: http.StatusUnsupportedMediaType,
+		Title:      "unsupported media type",
+		Message:    message,
+		devMessage: devMessage,
+	}
+}
+
+func NewPayloadTooLargeError(message, devMessage string) *Error {
+	return &Error{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Title:      "payload too large",
+		Message:    message,
+		devMessage: devMessage,
+	}
+}
+
+func NewServiceUnavailableError(devMessage string) *Error {
+	return &Error{
+		StatusCode: http.StatusServiceUnavailable,
+		Title:      "service unavailable",
+		Message:    "service is temporarily unavailable",
+		devMessage: devMessage,
+	}
+}
+
 func CompareError(e1, e2 *Error) bool {
 	return assert.ObjectsAreEqual(e1, e2)
 }
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/lib/pq"
+)
+
+// Sentinel errors to match translated errors against with errors.Is.
+var (
+	ErrNotFound           = &Error{StatusCode: http.StatusNotFound, Title: "not found"}
+	ErrConflict           = &Error{StatusCode: http.StatusConflict, Title: "conflict"}
+	ErrPreconditionFailed = &Error{StatusCode: http.StatusPreconditionFailed, Title: "precondition failed"}
+	ErrServiceUnavailable = &Error{StatusCode: http.StatusServiceUnavailable, Title: "service unavailable"}
+)
+
+// Translate maps an error returned by a repository (couchbase, postgresql) to an *Error
+// carrying the matching status code. The original error stays reachable through errors.Unwrap.
+// Errors that already are an *Error with a message are returned as is.
+func Translate(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if As(err, &e) && e.Message != "" {
+		return e
+	}
+
+	switch {
+	case Is(err, ErrNotFound), Is(err, gocb.ErrDocumentNotFound), Is(err, sql.ErrNoRows):
+		e = NewNotFoundError("resource not found", err.Error())
+	case Is(err, ErrConflict), Is(err, gocb.ErrDocumentExists), isPQError(err, "unique_violation", "foreign_key_violation"):
+		e = NewConflictError("resource conflicts with the current state", err.Error())
+	case Is(err, ErrPreconditionFailed), Is(err, gocb.ErrCasMismatch):
+		e = NewPreconditionFailedError("resource has been modified", err.Error())
+	case Is(err, ErrServiceUnavailable), Is(err, gocb.ErrTimeout), Is(err, gocb.ErrServiceNotAvailable),
+		Is(err, gocb.ErrTemporaryFailure), Is(err, context.DeadlineExceeded), Is(err, driver.ErrBadConn):
+		e = NewServiceUnavailableError(err.Error())
+	default:
+		e = NewInternalServerError(err.Error())
+	}
+
+	e.cause = err
+	return e
+}
+
+func isPQError(err error, names ...string) bool {
+	var pqErr *pq.Error
+	if !As(err, &pqErr) {
+		return false
+	}
+
+	for _, name := range names {
+		if pqErr.Code.Name() == name {
+			return true
+		}
+	}
+	return false
+}
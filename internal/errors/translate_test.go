@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		Given    error
+		Expected int
+		Sentinel error
+	}{
+		"should map missing couchbase document to not found": {
+			Given:    Wrap(gocb.ErrDocumentNotFound, "couchbase query"),
+			Expected: http.StatusNotFound,
+			Sentinel: ErrNotFound,
+		},
+		"should map missing postgresql row to not found": {
+			Given:    Wrap(sql.ErrNoRows, "postgresql query"),
+			Expected: http.StatusNotFound,
+			Sentinel: ErrNotFound,
+		},
+		"should map existing couchbase document to conflict": {
+			Given:    Wrap(gocb.ErrDocumentExists, "couchbase query"),
+			Expected: http.StatusConflict,
+			Sentinel: ErrConflict,
+		},
+		"should map postgresql unique violation to conflict": {
+			Given:    Wrap(&pq.Error{Code: "23505"}, "postgresql query"),
+			Expected: http.StatusConflict,
+			Sentinel: ErrConflict,
+		},
+		"should map postgresql foreign key violation to conflict": {
+			Given:    Wrap(&pq.Error{Code: "23503"}, "postgresql query"),
+			Expected: http.StatusConflict,
+			Sentinel: ErrConflict,
+		},
+		"should map cas mismatch to precondition failed": {
+			Given:    Wrap(gocb.ErrCasMismatch, "couchbase query"),
+			Expected: http.StatusPreconditionFailed,
+			Sentinel: ErrPreconditionFailed,
+		},
+		"should map couchbase timeout to service unavailable": {
+			Given:    Wrap(gocb.ErrUnambiguousTimeout, "couchbase query"),
+			Expected: http.StatusServiceUnavailable,
+			Sentinel: ErrServiceUnavailable,
+		},
+		"should map context deadline to service unavailable": {
+			Given:    Wrap(context.DeadlineExceeded, "postgresql query"),
+			Expected: http.StatusServiceUnavailable,
+			Sentinel: ErrServiceUnavailable,
+		},
+		"should map wrapped sentinel to its status code": {
+			Given:    Wrap(ErrPreconditionFailed, "version mismatch"),
+			Expected: http.StatusPreconditionFailed,
+			Sentinel: ErrPreconditionFailed,
+		},
+		"should map unknown error to internal server error": {
+			Given:    New("boom"),
+			Expected: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual := Translate(tc.Given)
+
+			assert.Equal(t, tc.Expected, actual.StatusCode)
+			assert.Equal(t, tc.Given.Error(), actual.Error())
+			assert.True(t, Is(actual, tc.Given))
+			if tc.Sentinel != nil {
+				assert.True(t, Is(actual, tc.Sentinel))
+			}
+		})
+	}
+}
+
+func TestTranslate_ShouldKeepErrorWithMessage(t *testing.T) {
+	given := NewBadRequestError("id must be integer", "id conversion")
+
+	actual := Translate(Wrap(given, "handle"))
+
+	assert.Same(t, given, actual)
+
+	var e *Error
+	assert.True(t, As(Wrap(actual, "handle"), &e))
+	assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+}
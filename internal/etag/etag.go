@@ -0,0 +1,87 @@
+package etag
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+const Any = "*"
+
+// Format returns the strong entity tag of a document revision identified by its cas value.
+func Format(cas uint64) string {
+	return `"` + strconv.FormatUint(cas, 36) + `"`
+}
+
+// FormatVariant returns the strong entity tag of a representation of a document revision which
+// also depends on the variant, like the fields it is projected to. The variant is hashed into the
+// tag after the cas value.
+func FormatVariant(cas uint64, variant ...string) string {
+	h := fnv.New64a()
+	for _, v := range variant {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return `"` + strconv.FormatUint(cas, 36) + "-" + strconv.FormatUint(h.Sum64(), 36) + `"`
+}
+
+// MatchStrong reports whether one of the entity tags in an If-Match header value matches the
+// given cas value with the strong comparison. Weak tags never match, so that they cannot satisfy
+// the precondition of a write. Variant tags match by the document revision they were formatted from.
+func MatchStrong(header string, cas uint64) bool {
+	for _, t := range split(header) {
+		if t == Any || document(t) == Format(cas) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchWeak reports whether one of the entity tags in an If-None-Match header value matches the
+// given entity tag with the weak comparison, which compares the opaque values only.
+func MatchWeak(header, tag string) bool {
+	for _, t := range split(header) {
+		if t == Any || strings.TrimPrefix(t, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Cas returns the cas value of a header value holding a single strong entity tag.
+func Cas(header string) (uint64, error) {
+	tags := split(header)
+	if len(tags) != 1 || tags[0] == Any || strings.HasPrefix(tags[0], "W/") {
+		return 0, errors.New("header must hold a single strong entity tag")
+	}
+
+	cas, err := strconv.ParseUint(strings.Trim(document(tags[0]), `"`), 36, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "entity tag parse")
+	}
+	return cas, nil
+}
+
+// document returns the entity tag of the document revision a strong tag was formatted from,
+// dropping its variant.
+func document(tag string) string {
+	if strings.HasPrefix(tag, "W/") {
+		return ""
+	}
+	if i := strings.IndexByte(tag, '-'); i > 0 {
+		return tag[:i] + `"`
+	}
+	return tag
+}
+
+func split(header string) []string {
+	var tags []string
+	for _, t := range strings.Split(header, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,75 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchStrong(t *testing.T) {
+	testCases := map[string]struct {
+		Header   string
+		Cas      uint64
+		Expected bool
+	}{
+		"should match same entity tag":          {Header: Format(42), Cas: 42, Expected: true},
+		"should match one of the entity tags":   {Header: Format(1) + ", " + Format(42), Cas: 42, Expected: true},
+		"should match any":                      {Header: "*", Cas: 42, Expected: true},
+		"should match variant entity tag":       {Header: FormatVariant(42, "Title"), Cas: 42, Expected: true},
+		"should not match weak entity tag":      {Header: "W/" + Format(42), Cas: 42, Expected: false},
+		"should not match other variant tag":    {Header: FormatVariant(1, "Title"), Cas: 42, Expected: false},
+		"should not match other entity tag":     {Header: Format(1), Cas: 42, Expected: false},
+		"should not match empty header":         {Header: "", Cas: 42, Expected: false},
+		"should not match unquoted entity tags": {Header: "16", Cas: 42, Expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, MatchStrong(tc.Header, tc.Cas))
+		})
+	}
+}
+
+func TestMatchWeak(t *testing.T) {
+	testCases := map[string]struct {
+		Header   string
+		Tag      string
+		Expected bool
+	}{
+		"should match same entity tag":         {Header: Format(42), Tag: Format(42), Expected: true},
+		"should match weak entity tag":         {Header: "W/" + Format(42), Tag: Format(42), Expected: true},
+		"should match weak current entity tag": {Header: Format(42), Tag: "W/" + Format(42), Expected: true},
+		"should match one of the entity tags":  {Header: Format(1) + ", W/" + Format(42), Tag: Format(42), Expected: true},
+		"should match any":                     {Header: "*", Tag: Format(42), Expected: true},
+		"should not match other entity tag":    {Header: "W/" + Format(1), Tag: Format(42), Expected: false},
+		"should not match empty header":        {Header: "", Tag: Format(42), Expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, MatchWeak(tc.Header, tc.Tag))
+		})
+	}
+}
+
+func TestFormatVariant(t *testing.T) {
+	assert.Equal(t, FormatVariant(42, "Title", "en"), FormatVariant(42, "Title", "en"))
+	assert.NotEqual(t, FormatVariant(42, "Title", "en"), FormatVariant(42, "Title", "de"))
+	assert.NotEqual(t, FormatVariant(42, "Title", "en"), FormatVariant(42, "Titleen"))
+	assert.NotEqual(t, FormatVariant(42, "Title"), FormatVariant(43, "Title"))
+}
+
+func TestCas(t *testing.T) {
+	cas, err := Cas(Format(1680000000000000000))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1680000000000000000), cas)
+
+	cas, err = Cas(FormatVariant(42, "Title"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), cas)
+
+	for _, header := range []string{"", "*", "W/" + Format(1), Format(1) + "," + Format(2), `"!"`} {
+		_, err := Cas(header)
+		assert.Error(t, err, header)
+	}
+}
@@ -0,0 +1,126 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// Query holds the sparse fieldset query parameters. Embed it into the request model of a
+// route so that the parameters are documented the same way everywhere.
+type Query struct {
+	Fields  []string `query:"fields" description:"comma separated fields to return, all fields when absent"`
+	Embed   []string `query:"embed" description:"comma separated heavy fields to return, none when given empty"`
+	Exclude []string `query:"exclude" description:"comma separated fields to leave out"`
+}
+
+// Parse reads the comma separated or repeated parameters. A present but empty embed
+// parameter embeds no heavy field, which differs from an absent one.
+func (q *Query) Parse(query url.Values) {
+	q.Fields, q.Exclude = split(query["fields"]), split(query["exclude"])
+
+	q.Embed = nil
+	if values, ok := query["embed"]; ok {
+		q.Embed = append([]string{}, split(values)...)
+	}
+}
+
+func split(values []string) []string {
+	var fields []string
+	for _, v := range values {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// Set is a selection of top level JSON fields. A nil Set selects every field.
+type Set []string
+
+// Resolve returns the fields selected out of all, in their order. Heavy fields are dropped
+// unless embedded when embed is given, and the key fields are always selected.
+func (q Query) Resolve(all, heavy []string, key ...string) (Set, error) {
+	for _, f := range append(append([]string{}, q.Fields...), q.Exclude...) {
+		if !contains(all, f) {
+			return nil, errors.NewBadRequestError("unknown field "+f, "fieldset "+f)
+		}
+	}
+	for _, f := range q.Embed {
+		if !contains(heavy, f) {
+			return nil, errors.NewBadRequestError("field "+f+" cannot be embedded", "fieldset embed "+f)
+		}
+	}
+
+	var set Set
+	for _, f := range all {
+		selected := len(q.Fields) == 0 || contains(q.Fields, f)
+		if q.Embed != nil && contains(heavy, f) {
+			selected = contains(q.Embed, f)
+		}
+		if contains(q.Exclude, f) {
+			selected = false
+		}
+
+		if selected || contains(key, f) {
+			set = append(set, f)
+		}
+	}
+
+	if len(set) == len(all) {
+		return nil, nil
+	}
+	return set, nil
+}
+
+// Project returns v without the fields which are not in the set. v must encode to
+// an object or an array of objects.
+func (s Set) Project(v any) (any, error) {
+	if s == nil {
+		return v, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "fieldset marshal")
+	}
+
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, errors.Wrap(err, "fieldset unmarshal")
+	}
+
+	switch doc := doc.(type) {
+	case map[string]any:
+		return s.project(doc), nil
+	case []any:
+		for i, elem := range doc {
+			if obj, ok := elem.(map[string]any); ok {
+				doc[i] = s.project(obj)
+			}
+		}
+	}
+	return doc, nil
+}
+
+func (s Set) project(doc map[string]any) map[string]any {
+	for k := range doc {
+		if !contains(s, k) {
+			delete(doc, k)
+		}
+	}
+	return doc
+}
+
+func contains(fields []string, f string) bool {
+	for _, x := range fields {
+		if x == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package fieldset
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_Resolve(t *testing.T) {
+	all := []string{"ID", "Title", "Genres", "Cast", "Story"}
+	heavy := []string{"Cast", "Story"}
+
+	testCases := map[string]struct {
+		Query    string
+		Expected Set
+		Error    bool
+	}{
+		"should select every field when absent": {
+			Query:    "",
+			Expected: nil,
+		},
+		"should select fields and key": {
+			Query:    "fields=Title,Genres",
+			Expected: Set{"ID", "Title", "Genres"},
+		},
+		"should accept repeated fields": {
+			Query:    "fields=Title&fields=Cast",
+			Expected: Set{"ID", "Title", "Cast"},
+		},
+		"should drop heavy fields not embedded": {
+			Query:    "embed=Cast",
+			Expected: Set{"ID", "Title", "Genres", "Cast"},
+		},
+		"should drop every heavy field when embed is empty": {
+			Query:    "embed=",
+			Expected: Set{"ID", "Title", "Genres"},
+		},
+		"should exclude fields": {
+			Query:    "exclude=Story,Genres",
+			Expected: Set{"ID", "Title", "Cast"},
+		},
+		"should keep key when excluded": {
+			Query:    "fields=Title&exclude=ID",
+			Expected: Set{"ID", "Title"},
+		},
+		"should reject unknown field": {
+			Query: "fields=Budget",
+			Error: true,
+		},
+		"should reject embedding light field": {
+			Query: "embed=Title",
+			Error: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tc.Query)
+
+			var q Query
+			q.Parse(query)
+			actual, err := q.Resolve(all, heavy, "ID")
+
+			if tc.Error {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestSet_Project(t *testing.T) {
+	type movie struct {
+		ID    int
+		Title string
+		Story string
+	}
+
+	actual, err := Set{"ID", "Title"}.Project([]movie{{ID: 1, Title: "Heat", Story: "long"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"ID": float64(1), "Title": "Heat"}}, actual)
+}
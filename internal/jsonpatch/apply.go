@@ -0,0 +1,287 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+const (
+	OpMove = "move"
+	OpCopy = "copy"
+	OpTest = "test"
+)
+
+// Media types of the RFC 6902 JSON Patch and RFC 7386 JSON Merge Patch documents.
+const (
+	MediaType      = "application/json-patch+json"
+	MergeMediaType = "application/merge-patch+json"
+)
+
+// ErrTestFailed is returned by Apply when a test operation does not match the document.
+var ErrTestFailed = errors.New("test operation failed")
+
+// Apply applies the RFC 6902 operations in order to the JSON document. The patch is applied
+// as a whole: when an operation fails, the error is returned and the document is left as is.
+func Apply(doc []byte, ops []Operation) ([]byte, error) {
+	var d any
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return nil, errors.Wrap(err, "document unmarshal")
+	}
+
+	for i, op := range ops {
+		var err error
+		if d, err = apply(d, op); err != nil {
+			return nil, errors.Wrap(err, "operation "+strconv.Itoa(i))
+		}
+	}
+
+	return json.Marshal(d)
+}
+
+func apply(doc any, op Operation) (any, error) {
+	path, err := ParsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case OpAdd:
+		return add(doc, path, op.Value)
+	case OpRemove:
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case OpReplace:
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, err
+		}
+		return add(doc, path, op.Value)
+	case OpMove, OpCopy:
+		from, err := ParsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+		if op.Op == OpMove {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, errors.New("cannot move " + op.From + " into itself")
+			}
+			doc, value, err = remove(doc, from)
+		} else {
+			value, err = get(doc, from)
+			value = normalizeValue(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case OpTest:
+		value, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, normalizeValue(op.Value)) {
+			return nil, errors.Wrap(ErrTestFailed, op.Path)
+		}
+		return doc, nil
+	}
+	return nil, errors.New("unknown operation " + op.Op)
+}
+
+// ParsePointer splits an RFC 6901 JSON pointer into its unescaped tokens.
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("invalid pointer " + pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, t := range path {
+		switch d := doc.(type) {
+		case map[string]any:
+			v, ok := d[t]
+			if !ok {
+				return nil, errors.New("member " + t + " not found")
+			}
+			doc = v
+		case []any:
+			i, err := index(t, len(d)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = d[i]
+		default:
+			return nil, errors.New("cannot traverse " + t)
+		}
+	}
+	return doc, nil
+}
+
+// add sets the value at the path and returns the document, which is replaced for an empty path.
+func add(doc any, path []string, value any) (any, error) {
+	value = normalizeValue(value)
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]any:
+		p[last] = value
+		return doc, nil
+	case []any:
+		i := len(p)
+		if last != "-" {
+			if i, err = index(last, len(p)); err != nil {
+				return nil, err
+			}
+		}
+
+		arr := append(p[:i:i], append([]any{value}, p[i:]...)...)
+		return set(doc, path[:len(path)-1], arr)
+	}
+	return nil, errors.New("cannot add to " + last)
+}
+
+// remove deletes the value at the path and returns the document and the removed value.
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]any:
+		v, ok := p[last]
+		if !ok {
+			return nil, nil, errors.New("member " + last + " not found")
+		}
+		delete(p, last)
+		return doc, v, nil
+	case []any:
+		i, err := index(last, len(p)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		v := p[i]
+		arr := append(p[:i:i], p[i+1:]...)
+		doc, err = set(doc, path[:len(path)-1], arr)
+		return doc, v, err
+	}
+	return nil, nil, errors.New("cannot remove " + last)
+}
+
+// set replaces the value at the path, used for arrays which change length.
+func set(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]any:
+		p[last] = value
+	case []any:
+		i, err := index(last, len(p)-1)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = value
+	}
+	return doc, nil
+}
+
+// index parses an array index, which cannot be greater than max.
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, errors.New("invalid array index " + token)
+	}
+	return i, nil
+}
+
+// normalizeValue returns a copy of the value in its JSON form, so that it compares with
+// the decoded document and shares no maps or slices with it.
+func normalizeValue(v any) any {
+	n, err := normalize(v)
+	if err != nil {
+		return v
+	}
+	return n
+}
+
+// Merge applies the RFC 7386 merge patch to the JSON document: object members of the patch
+// replace the members of the document recursively and null members remove them.
+func Merge(doc, patch []byte) ([]byte, error) {
+	var d, p any
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return nil, errors.Wrap(err, "document unmarshal")
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, errors.Wrap(err, "patch unmarshal")
+	}
+
+	return json.Marshal(merge(d, p))
+}
+
+func merge(doc, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	d, ok := doc.(map[string]any)
+	if !ok {
+		d = map[string]any{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(d, k)
+			continue
+		}
+		d[k] = merge(d[k], v)
+	}
+	return d
+}
@@ -0,0 +1,138 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value"`
+}
+
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	if o.Op == OpRemove {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	return json.Marshal(operation(o))
+}
+
+// Diff returns the operations which turn the JSON document a into b.
+// Both documents are compared in their JSON form, so struct values can be passed directly.
+func Diff(a, b any) ([]Operation, error) {
+	da, err := normalize(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "source document")
+	}
+
+	db, err := normalize(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "target document")
+	}
+
+	return diff("", da, db), nil
+}
+
+func diff(path string, a, b any) []Operation {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			return diffObject(path, av, bv)
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			return diffArray(path, av, bv)
+		}
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	return []Operation{{Op: OpReplace, Path: path, Value: b}}
+}
+
+func diffObject(path string, a, b map[string]any) []Operation {
+	var ops []Operation
+	for _, k := range sortedKeys(a) {
+		bv, ok := b[k]
+		if !ok {
+			ops = append(ops, Operation{Op: OpRemove, Path: path + "/" + escape(k)})
+			continue
+		}
+		ops = append(ops, diff(path+"/"+escape(k), a[k], bv)...)
+	}
+
+	for _, k := range sortedKeys(b) {
+		if _, ok := a[k]; !ok {
+			ops = append(ops, Operation{Op: OpAdd, Path: path + "/" + escape(k), Value: b[k]})
+		}
+	}
+	return ops
+}
+
+// diffArray compares arrays index by index. Trailing elements are removed from the end first
+// so that the indexes of the remaining operations stay valid when the patch is applied in order.
+func diffArray(path string, a, b []any) []Operation {
+	var ops []Operation
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	for i := 0; i < n; i++ {
+		ops = append(ops, diff(path+"/"+strconv.Itoa(i), a[i], b[i])...)
+	}
+
+	for i := len(a) - 1; i >= n; i-- {
+		ops = append(ops, Operation{Op: OpRemove, Path: path + "/" + strconv.Itoa(i)})
+	}
+
+	for i := n; i < len(b); i++ {
+		ops = append(ops, Operation{Op: OpAdd, Path: path + "/-", Value: b[i]})
+	}
+	return ops
+}
+
+func normalize(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal")
+	}
+
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+	return doc, nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
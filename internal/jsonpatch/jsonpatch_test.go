@@ -0,0 +1,187 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := map[string]struct {
+		A        string
+		B        string
+		Expected []Operation
+	}{
+		"should return no operation for equal documents": {
+			A: `{"Title":"Heat","Genres":["Crime"]}`,
+			B: `{"Genres":["Crime"],"Title":"Heat"}`,
+		},
+		"should replace changed value": {
+			A:        `{"Title":"Heat","Runtime":170}`,
+			B:        `{"Title":"Heat","Runtime":0}`,
+			Expected: []Operation{{Op: OpReplace, Path: "/Runtime", Value: float64(0)}},
+		},
+		"should add and remove object members": {
+			A: `{"Title":"Heat","DeletedBy":"a@b.com"}`,
+			B: `{"Title":"Heat","Story":"..."}`,
+			Expected: []Operation{
+				{Op: OpRemove, Path: "/DeletedBy"},
+				{Op: OpAdd, Path: "/Story", Value: "..."},
+			},
+		},
+		"should diff nested arrays by index": {
+			A: `{"Cast":[{"Name":"Al"},{"Name":"Bob"},{"Name":"Val"}]}`,
+			B: `{"Cast":[{"Name":"Al"},{"Name":"Robert"}]}`,
+			Expected: []Operation{
+				{Op: OpReplace, Path: "/Cast/1/Name", Value: "Robert"},
+				{Op: OpRemove, Path: "/Cast/2"},
+			},
+		},
+		"should append new array elements": {
+			A:        `{"Genres":["Crime"]}`,
+			B:        `{"Genres":["Crime","Drama"]}`,
+			Expected: []Operation{{Op: OpAdd, Path: "/Genres/-", Value: "Drama"}},
+		},
+		"should escape member names": {
+			A:        `{"a/b":1}`,
+			B:        `{"a/b":2}`,
+			Expected: []Operation{{Op: OpReplace, Path: "/a~1b", Value: float64(2)}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Diff(json.RawMessage(tc.A), json.RawMessage(tc.B))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestOperation_MarshalJSON(t *testing.T) {
+	actual, err := json.Marshal([]Operation{
+		{Op: OpRemove, Path: "/Story"},
+		{Op: OpReplace, Path: "/Story", Value: nil},
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"remove","path":"/Story"},{"op":"replace","path":"/Story","value":null}]`, string(actual))
+}
+
+func TestApply(t *testing.T) {
+	testCases := map[string]struct {
+		Doc      string
+		Ops      []Operation
+		Expected string
+		Err      error
+	}{
+		"should add, replace and remove members": {
+			Doc: `{"Title":"Heat","Runtime":170,"Story":"..."}`,
+			Ops: []Operation{
+				{Op: OpAdd, Path: "/Language", Value: "en"},
+				{Op: OpReplace, Path: "/Runtime", Value: 171},
+				{Op: OpRemove, Path: "/Story"},
+			},
+			Expected: `{"Language":"en","Runtime":171,"Title":"Heat"}`,
+		},
+		"should insert and append array elements": {
+			Doc: `{"Genres":["Crime"]}`,
+			Ops: []Operation{
+				{Op: OpAdd, Path: "/Genres/0", Value: "Action"},
+				{Op: OpAdd, Path: "/Genres/-", Value: "Drama"},
+			},
+			Expected: `{"Genres":["Action","Crime","Drama"]}`,
+		},
+		"should move and copy values": {
+			Doc: `{"Cast":[{"Name":"Al"},{"Name":"Bob"}],"Title":"Heat"}`,
+			Ops: []Operation{
+				{Op: OpMove, From: "/Cast/1", Path: "/Cast/0"},
+				{Op: OpCopy, From: "/Title", Path: "/OriginalTitle"},
+			},
+			Expected: `{"Cast":[{"Name":"Bob"},{"Name":"Al"}],"OriginalTitle":"Heat","Title":"Heat"}`,
+		},
+		"should unescape member names": {
+			Doc:      `{"a/b":1,"c~d":2}`,
+			Ops:      []Operation{{Op: OpRemove, Path: "/a~1b"}, {Op: OpReplace, Path: "/c~0d", Value: 3}},
+			Expected: `{"c~d":3}`,
+		},
+		"should pass matching test": {
+			Doc:      `{"Cast":[{"Name":"Al"}]}`,
+			Ops:      []Operation{{Op: OpTest, Path: "/Cast/0", Value: map[string]any{"Name": "Al"}}},
+			Expected: `{"Cast":[{"Name":"Al"}]}`,
+		},
+		"should fail mismatching test": {
+			Doc: `{"Runtime":170}`,
+			Ops: []Operation{{Op: OpTest, Path: "/Runtime", Value: 171}},
+			Err: ErrTestFailed,
+		},
+		"should fail on missing member": {
+			Doc: `{"Title":"Heat"}`,
+			Ops: []Operation{{Op: OpReplace, Path: "/Story", Value: "..."}},
+			Err: errors.New("operation 0: member Story not found"),
+		},
+		"should fail on out of range index": {
+			Doc: `{"Genres":["Crime"]}`,
+			Ops: []Operation{{Op: OpAdd, Path: "/Genres/2", Value: "Drama"}},
+			Err: errors.New("operation 0: invalid array index 2"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Apply([]byte(tc.Doc), tc.Ops)
+
+			if tc.Err != nil {
+				if errors.Is(tc.Err, ErrTestFailed) {
+					assert.ErrorIs(t, err, ErrTestFailed)
+				} else {
+					assert.EqualError(t, err, tc.Err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.Expected, string(actual))
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	testCases := map[string]struct {
+		Doc      string
+		Patch    string
+		Expected string
+	}{
+		"should replace and add members": {
+			Doc:      `{"Title":"Heat","Runtime":170}`,
+			Patch:    `{"Runtime":171,"Language":"en"}`,
+			Expected: `{"Title":"Heat","Runtime":171,"Language":"en"}`,
+		},
+		"should remove null members": {
+			Doc:      `{"Title":"Heat","Story":"..."}`,
+			Patch:    `{"Story":null}`,
+			Expected: `{"Title":"Heat"}`,
+		},
+		"should merge nested objects": {
+			Doc:      `{"Rating":{"Imdb":8.3,"Votes":10}}`,
+			Patch:    `{"Rating":{"Votes":null,"Tmdb":7.9}}`,
+			Expected: `{"Rating":{"Imdb":8.3,"Tmdb":7.9}}`,
+		},
+		"should replace arrays as a whole": {
+			Doc:      `{"Genres":["Crime","Drama"]}`,
+			Patch:    `{"Genres":["Action"]}`,
+			Expected: `{"Genres":["Action"]}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Merge([]byte(tc.Doc), []byte(tc.Patch))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.Expected, string(actual))
+		})
+	}
+}
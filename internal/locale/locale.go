@@ -0,0 +1,95 @@
+// Package locale handles the language tags movies are translated to: a language, an optional
+// script and an optional region, like en, pt-BR or zh-Hant-TW.
+package locale
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+var tagPattern = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:-([a-zA-Z]{4}))?(?:-([a-zA-Z]{2}|[0-9]{3}))?$`)
+
+// Canonical returns the tag with its subtags in their conventional case, like pt-BR or zh-Hant.
+func Canonical(tag string) (string, error) {
+	m := tagPattern.FindStringSubmatch(strings.ReplaceAll(tag, "_", "-"))
+	if m == nil {
+		return "", errors.New("invalid language tag " + tag)
+	}
+
+	canonical := strings.ToLower(m[1])
+	if m[2] != "" {
+		canonical += "-" + strings.ToUpper(m[2][:1]) + strings.ToLower(m[2][1:])
+	}
+	if m[3] != "" {
+		canonical += "-" + strings.ToUpper(m[3])
+	}
+	return canonical, nil
+}
+
+// Fallbacks returns the tag followed by the tags it falls back to, its subtags removed from
+// the end one by one: zh-Hant-TW, zh-Hant, zh.
+func Fallbacks(tag string) []string {
+	chain := []string{tag}
+	for i := strings.LastIndexByte(tag, '-'); i > 0; i = strings.LastIndexByte(tag, '-') {
+		tag = tag[:i]
+		chain = append(chain, tag)
+	}
+	return chain
+}
+
+// ParseAcceptLanguage returns the canonical tags of an Accept-Language header, most preferred
+// first. The wildcard, invalid tags and tags with a zero quality are left out.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+
+		tag, err := Canonical(strings.TrimSpace(fields[0]))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if name == "q" {
+				if q, err = strconv.ParseFloat(value, 64); err != nil {
+					q = 0
+				}
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	tags := make([]string, len(ranges))
+	for i, r := range ranges {
+		tags[i] = r.tag
+	}
+	return tags
+}
+
+// Lookup returns the first available tag of the fallback chains of the preferred tags, taken
+// in order, so that pt-BR, en prefers pt over en.
+func Lookup(preferred []string, available func(tag string) bool) (string, bool) {
+	for _, p := range preferred {
+		for _, tag := range Fallbacks(p) {
+			if available(tag) {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
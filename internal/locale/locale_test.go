@@ -0,0 +1,86 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonical(t *testing.T) {
+	testCases := map[string]struct {
+		Tag      string
+		Expected string
+		Err      bool
+	}{
+		"should lower the language":        {Tag: "EN", Expected: "en"},
+		"should upper the region":          {Tag: "pt-br", Expected: "pt-BR"},
+		"should title the script":          {Tag: "zh-HANT-tw", Expected: "zh-Hant-TW"},
+		"should accept numeric regions":    {Tag: "es-419", Expected: "es-419"},
+		"should accept underscores":        {Tag: "en_GB", Expected: "en-GB"},
+		"should reject empty tags":         {Tag: "", Err: true},
+		"should reject long languages":     {Tag: "english", Err: true},
+		"should reject unknown subtags":    {Tag: "en-GB-oxendict", Err: true},
+		"should reject the wildcard range": {Tag: "*", Err: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tag, err := Canonical(tc.Tag)
+			if tc.Err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, tag)
+		})
+	}
+}
+
+func TestFallbacks(t *testing.T) {
+	assert.Equal(t, []string{"zh-Hant-TW", "zh-Hant", "zh"}, Fallbacks("zh-Hant-TW"))
+	assert.Equal(t, []string{"en"}, Fallbacks("en"))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	testCases := map[string]struct {
+		Header   string
+		Expected []string
+	}{
+		"should keep the header order":        {Header: "tr, en-us", Expected: []string{"tr", "en-US"}},
+		"should order by quality":             {Header: "en;q=0.5, de;q=0.9, fr", Expected: []string{"fr", "de", "en"}},
+		"should drop zero quality":            {Header: "en, de;q=0", Expected: []string{"en"}},
+		"should drop invalid tags":            {Header: "*, x-klingon, it", Expected: []string{"it"}},
+		"should drop invalid qualities":       {Header: "en;q=high, it", Expected: []string{"it"}},
+		"should return nothing for no header": {Header: "", Expected: []string{}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, ParseAcceptLanguage(tc.Header))
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	available := func(tags ...string) func(string) bool {
+		return func(tag string) bool {
+			for _, t := range tags {
+				if t == tag {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	tag, ok := Lookup([]string{"pt-BR", "en"}, available("en", "pt"))
+	assert.True(t, ok)
+	assert.Equal(t, "pt", tag)
+
+	tag, ok = Lookup([]string{"de", "en-GB"}, available("en", "fr"))
+	assert.True(t, ok)
+	assert.Equal(t, "en", tag)
+
+	_, ok = Lookup([]string{"de"}, available("en"))
+	assert.False(t, ok)
+}
@@ -9,19 +9,58 @@ import (
 	"github.com/3n0ugh/allotropes/internal/token"
 )
 
+type principalKey struct{}
+
+// secret verifies the token signatures, it is set once at startup.
+var secret string
+
+// SetSecret sets the secret the tokens are signed with. It has to be called before serving,
+// Auth rejects every token until then.
+func SetSecret(s string) {
+	secret = s
+}
+
 func Auth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secret == "" {
+			writeError(w, errors.NewUnAuthorizedError("unauthorized", "token secret is not set"))
+			return
+		}
+
 		claim := token.Claims{}
-		err := claim.ParseToken(r.Header.Get("authorization"))
+		err := claim.ParseToken(secret, r.Header.Get("authorization"))
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
-			w.WriteHeader(http.StatusUnauthorized)
-			res, _ := json.Marshal(errors.NewUnAuthorizedError("unauthorized", err.Error()))
-			w.Write(res)
+			writeError(w, errors.NewUnAuthorizedError("unauthorized", err.Error()))
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), "email", claim.Email)
+		ctx := context.WithValue(r.Context(), principalKey{}, claim)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// Admin lets only admin principals through. It has to be chained after Auth.
+func Admin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claim, ok := Principal(r.Context())
+		if !ok || !claim.IsAdmin() {
+			writeError(w, errors.NewForbiddenError("forbidden", "principal is not admin"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Principal returns the claims of the authenticated user Auth put in the context.
+func Principal(ctx context.Context) (token.Claims, bool) {
+	claim, ok := ctx.Value(principalKey{}).(token.Claims)
+	return claim, ok
+}
+
+func writeError(w http.ResponseWriter, e *errors.Error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(e.StatusCode)
+	res, _ := json.Marshal(e)
+	w.Write(res)
+}
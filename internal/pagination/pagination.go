@@ -1,12 +1,182 @@
 package pagination
 
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+const (
+	DefaultSize = 20
+	MaxSize     = 100
+)
+
+// Request holds the pagination query parameters. Embed it into the request model of a
+// listing route so that the parameters are documented the same way everywhere.
+type Request struct {
+	Page int `query:"page" description:"zero based page number"`
+	Size int `query:"size" description:"number of items per page, at most 100"`
+}
+
+// Parse reads page and size from the query. Absent parameters fall back to the first page
+// and the default size.
+func (r *Request) Parse(query url.Values) error {
+	r.Page, r.Size = 0, DefaultSize
+
+	if p := query.Get("page"); p != "" {
+		page, err := strconv.Atoi(p)
+		if err != nil || page < 0 {
+			return errors.NewBadRequestError("page must be a non negative integer", "page conversion")
+		}
+		r.Page = page
+	}
+
+	if s := query.Get("size"); s != "" {
+		size, err := strconv.Atoi(s)
+		if err != nil || size < 1 || size > MaxSize {
+			return errors.NewBadRequestError("size must be an integer between 1 and 100", "size conversion")
+		}
+		r.Size = size
+	}
+
+	return nil
+}
+
+func (r Request) Offset() int {
+	return r.Page * r.Size
+}
+
+// CursorRequest adds keyset pagination to Request. The cursor continues the listing right after
+// the last item of the previous page, so it stays stable while items are inserted.
+type CursorRequest struct {
+	Request
+	Cursor string `query:"cursor" description:"nextCursor of the previous page, cannot be combined with page"`
+}
+
+func (r *CursorRequest) Parse(query url.Values) error {
+	if err := r.Request.Parse(query); err != nil {
+		return err
+	}
+
+	r.Cursor = query.Get("cursor")
+	if r.Cursor != "" && query.Get("page") != "" {
+		return errors.NewBadRequestError("page and cursor cannot be combined", "page and cursor")
+	}
+	return nil
+}
+
+// Model describes where a page is within the listing. Navigation URLs keep every other
+// query parameter of the request.
 type Model struct {
 	TotalCount int `json:"-"`
 
-	Rel  string `json:"rel"`
-	Next string `json:"next"`
-	Prev string `json:"prev"`
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	TotalPages int    `json:"totalPages"`
+	First      string `json:"first"`
+	Last       string `json:"last"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+
+	keyset bool
+}
+
+func New(r Request, totalCount int) Model {
+	totalPages := (totalCount + r.Size - 1) / r.Size
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return Model{
+		TotalCount: totalCount,
+		Page:       r.Page,
+		Size:       r.Size,
+		TotalPages: totalPages,
+	}
+}
+
+// NewCursor returns the model of a listing which can be continued with the next cursor.
+// When the request itself used a cursor, navigation is done with cursors instead of pages.
+func NewCursor(r CursorRequest, totalCount int, next string) Model {
+	p := New(r.Request, totalCount)
+	p.NextCursor = next
+	p.keyset = r.Cursor != ""
+	return p
+}
+
+// Validate reports a page past the last one. The first page is always valid, even when empty.
+func (p Model) Validate() error {
+	if !p.keyset && p.Page >= p.TotalPages {
+		return errors.NewBadRequestError("page is out of range", "page "+strconv.Itoa(p.Page)+" of "+strconv.Itoa(p.TotalPages))
+	}
+	return nil
 }
 
-func (p *Model) Set() {
+// Set fills the navigation URLs relative to the requested URL.
+func (p *Model) Set(u *url.URL) {
+	if p.keyset {
+		p.First, p.Last, p.Prev, p.Next = pageURL(u, 0, p.Size), "", "", ""
+		if p.NextCursor != "" {
+			p.Next = cursorURL(u, p.NextCursor, p.Size)
+		}
+		return
+	}
+
+	p.First = pageURL(u, 0, p.Size)
+	p.Last = pageURL(u, p.TotalPages-1, p.Size)
+	p.Next, p.Prev = "", ""
+
+	if p.Page+1 < p.TotalPages {
+		p.Next = pageURL(u, p.Page+1, p.Size)
+	}
+
+	if p.Page > 0 {
+		p.Prev = pageURL(u, p.Page-1, p.Size)
+	}
+}
+
+// Link returns the RFC 8288 Link header value of the navigation URLs.
+func (p Model) Link() string {
+	links := make([]string, 0, 4)
+	for _, l := range []struct{ rel, url string }{
+		{"first", p.First},
+		{"prev", p.Prev},
+		{"next", p.Next},
+		{"last", p.Last},
+	} {
+		if l.url != "" {
+			links = append(links, "<"+l.url+`>; rel="`+l.rel+`"`)
+		}
+	}
+	return strings.Join(links, ", ")
+}
+
+// Write sets the navigation URLs and emits them as Link header.
+func (p *Model) Write(w http.ResponseWriter, r *http.Request) {
+	p.Set(r.URL)
+	if link := p.Link(); link != "" {
+		w.Header().Set("Link", link)
+	}
+}
+
+func pageURL(u *url.URL, page, size int) string {
+	q := u.Query()
+	q.Del("cursor")
+	q.Set("page", strconv.Itoa(page))
+	q.Set("size", strconv.Itoa(size))
+
+	return (&url.URL{Path: u.Path, RawQuery: q.Encode()}).String()
+}
+
+func cursorURL(u *url.URL, cursor string, size int) string {
+	q := u.Query()
+	q.Del("page")
+	q.Set("cursor", cursor)
+	q.Set("size", strconv.Itoa(size))
+
+	return (&url.URL{Path: u.Path, RawQuery: q.Encode()}).String()
 }
@@ -0,0 +1,106 @@
+package pagination
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Parse(t *testing.T) {
+	testCases := map[string]struct {
+		Query    string
+		Expected Request
+		IsValid  bool
+	}{
+		"should use defaults when absent":    {Query: "", Expected: Request{Page: 0, Size: DefaultSize}, IsValid: true},
+		"should parse page and size":         {Query: "page=2&size=5", Expected: Request{Page: 2, Size: 5}, IsValid: true},
+		"should reject negative page":        {Query: "page=-1"},
+		"should reject non integer page":     {Query: "page=abc"},
+		"should reject zero size":            {Query: "size=0"},
+		"should reject size larger than max": {Query: "size=101"},
+		"should ignore the legacy page size": {Query: "pageSize=5", Expected: Request{Page: 0, Size: DefaultSize}, IsValid: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tc.Query)
+
+			var actual Request
+			err := actual.Parse(q)
+
+			if !tc.IsValid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestModel_Validate(t *testing.T) {
+	assert.NoError(t, New(Request{Page: 0, Size: 20}, 0).Validate())
+	assert.NoError(t, New(Request{Page: 2, Size: 20}, 41).Validate())
+	assert.Error(t, New(Request{Page: 3, Size: 20}, 60).Validate())
+}
+
+func TestModel_Set(t *testing.T) {
+	u, _ := url.Parse("/v1/movies?genre=Drama&page=1&size=10")
+
+	actual := New(Request{Page: 1, Size: 10}, 35)
+	actual.Set(u)
+
+	assert.Equal(t, Model{
+		TotalCount: 35,
+		Page:       1,
+		Size:       10,
+		TotalPages: 4,
+		First:      "/v1/movies?genre=Drama&page=0&size=10",
+		Last:       "/v1/movies?genre=Drama&page=3&size=10",
+		Next:       "/v1/movies?genre=Drama&page=2&size=10",
+		Prev:       "/v1/movies?genre=Drama&page=0&size=10",
+	}, actual)
+
+	assert.Equal(t, `</v1/movies?genre=Drama&page=0&size=10>; rel="first", `+
+		`</v1/movies?genre=Drama&page=0&size=10>; rel="prev", `+
+		`</v1/movies?genre=Drama&page=2&size=10>; rel="next", `+
+		`</v1/movies?genre=Drama&page=3&size=10>; rel="last"`, actual.Link())
+}
+
+func TestModel_Set_ShouldOmitNextAndPrevOnSinglePage(t *testing.T) {
+	u, _ := url.Parse("/v1/movies")
+
+	actual := New(Request{Page: 0, Size: 20}, 3)
+	actual.Set(u)
+
+	assert.Empty(t, actual.Next)
+	assert.Empty(t, actual.Prev)
+	assert.Equal(t, `</v1/movies?page=0&size=20>; rel="first", </v1/movies?page=0&size=20>; rel="last"`, actual.Link())
+}
+
+func TestCursorRequest_Parse(t *testing.T) {
+	q, _ := url.ParseQuery("cursor=abc&size=5")
+
+	var actual CursorRequest
+	assert.NoError(t, actual.Parse(q))
+	assert.Equal(t, CursorRequest{Request: Request{Page: 0, Size: 5}, Cursor: "abc"}, actual)
+
+	q, _ = url.ParseQuery("cursor=abc&page=1")
+	assert.Error(t, actual.Parse(q))
+}
+
+func TestModel_Set_ShouldNavigateWithCursor(t *testing.T) {
+	u, _ := url.Parse("/v1/movies?cursor=abc&size=10")
+
+	actual := NewCursor(CursorRequest{Request: Request{Size: 10}, Cursor: "abc"}, 35, "def")
+	assert.NoError(t, actual.Validate())
+
+	actual.Set(u)
+
+	assert.Equal(t, "/v1/movies?page=0&size=10", actual.First)
+	assert.Equal(t, "/v1/movies?cursor=def&size=10", actual.Next)
+	assert.Empty(t, actual.Prev)
+	assert.Empty(t, actual.Last)
+	assert.Equal(t, `</v1/movies?page=0&size=10>; rel="first", </v1/movies?cursor=def&size=10>; rel="next"`, actual.Link())
+}
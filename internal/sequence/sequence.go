@@ -0,0 +1,151 @@
+package sequence
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/couchbase/gocb/v2"
+)
+
+const (
+	SourceCouchbase  = "couchbase"
+	SourcePostgreSQL = "postgresql"
+)
+
+// maxAttempts bounds the identifiers Insert draws for one document.
+const maxAttempts = 5
+
+// Sequence allocates increasing, never reused identifiers.
+type Sequence interface {
+	Next(ctx context.Context) (int, error)
+	// Seed moves the sequence to at least max, so that Next hands out identifiers above it.
+	Seed(ctx context.Context, max int) error
+}
+
+// Seed moves seq past the highest ID of the documents in the scope.collection keyspace, so that
+// it never hands out the ID of a document stored before the sequence was used.
+func Seed(ctx context.Context, seq Sequence, cb *gocb.Bucket, scope, collection string) error {
+	query := "SELECT RAW MAX(d.ID) FROM `" + scope + "`." + scope + "." + collection + " AS d"
+
+	res, err := cb.Scope(scope).Query(query, &gocb.QueryOptions{Context: ctx})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+
+	var max *int
+	if err := res.One(&max); err != nil {
+		return errors.Wrap(err, "row parse")
+	}
+	if max == nil {
+		return nil
+	}
+	return seq.Seed(ctx, *max)
+}
+
+// Insert draws identifiers from seq until insert stores the document under one that is not
+// taken yet, which skips the documents stored before the sequence was seeded.
+func Insert(ctx context.Context, seq Sequence, insert func(id int) error) (int, error) {
+	for attempt := 1; ; attempt++ {
+		id, err := seq.Next(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		err = insert(id)
+		if errors.Is(err, gocb.ErrDocumentExists) && attempt < maxAttempts {
+			continue
+		}
+		return id, err
+	}
+}
+
+// New returns the sequence called name backed by the given source.
+func New(source, name string, cb *gocb.Bucket, db *sql.DB) Sequence {
+	if source == SourcePostgreSQL {
+		return NewPostgreSQL(db, name)
+	}
+	return NewCouchbase(cb, name)
+}
+
+type couchbase struct {
+	repo *gocb.Bucket
+	key  string
+}
+
+// NewCouchbase returns a sequence backed by a counter document in the default collection,
+// so that it never shows up in queries over the resource collections.
+func NewCouchbase(repo *gocb.Bucket, name string) Sequence {
+	return &couchbase{repo: repo, key: name + "::id"}
+}
+
+func (s *couchbase) Next(_ context.Context) (int, error) {
+	res, err := s.repo.DefaultCollection().Binary().Increment(s.key, &gocb.IncrementOptions{
+		Initial: 1,
+		Delta:   1,
+		Timeout: 3 * time.Second,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase counter")
+	}
+	return int(res.Content()), nil
+}
+
+func (s *couchbase) Seed(_ context.Context, max int) error {
+	if max < 1 {
+		return nil
+	}
+
+	counter := s.repo.DefaultCollection().Binary()
+
+	res, err := counter.Increment(s.key, &gocb.IncrementOptions{Initial: int64(max), Delta: 0, Timeout: 3 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "couchbase counter")
+	}
+	if current := int(res.Content()); current < max {
+		_, err = counter.Increment(s.key, &gocb.IncrementOptions{Initial: int64(max), Delta: uint64(max - current), Timeout: 3 * time.Second})
+		if err != nil {
+			return errors.Wrap(err, "couchbase counter")
+		}
+	}
+	return nil
+}
+
+type postgresql struct {
+	db   *sql.DB
+	name string
+}
+
+// NewPostgreSQL returns a sequence backed by the PostgreSQL sequence <name>_id_seq.
+func NewPostgreSQL(db *sql.DB, name string) Sequence {
+	return &postgresql{db: db, name: name + "_id_seq"}
+}
+
+func (s *postgresql) Next(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var id int
+	if err := s.db.QueryRowContext(ctx, "SELECT nextval($1::regclass)", s.name).Scan(&id); err != nil {
+		return 0, errors.Wrap(err, "postgresql sequence")
+	}
+	return id, nil
+}
+
+func (s *postgresql) Seed(ctx context.Context, max int) error {
+	if max < 1 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `SELECT setval($1::text::regclass, $2::bigint)
+		WHERE $2::bigint > (SELECT coalesce(last_value, 0) FROM pg_sequences WHERE sequencename = $1::text)`
+
+	if _, err := s.db.ExecContext(ctx, query, s.name, max); err != nil {
+		return errors.Wrap(err, "postgresql sequence")
+	}
+	return nil
+}
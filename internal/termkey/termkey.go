@@ -0,0 +1,21 @@
+// Package termkey folds the spellings of genres and keywords onto lookup keys.
+package termkey
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Pattern matches the runes Fold drops, for queries folding values the same way.
+const Pattern = `[^\pL\pN]`
+
+// Fold returns the lowercased letters and digits of s: "Sci-Fi", "sci fi" and "SciFi" share "scifi".
+func Fold(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package termkey
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	testCases := map[string]struct {
+		Value string
+		Key   string
+	}{
+		"should drop case and punctuation": {Value: "Sci-Fi", Key: "scifi"},
+		"should drop spaces":               {Value: " sci fi ", Key: "scifi"},
+		"should keep letters and digits":   {Value: "Film-Noir 1940s", Key: "filmnoir1940s"},
+		"should keep non latin letters":    {Value: "Ünlü Yapım", Key: "ünlüyapım"},
+	}
+
+	pattern := regexp.MustCompile(Pattern)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Key, Fold(tc.Value))
+			assert.Equal(t, tc.Key, pattern.ReplaceAllString(strings.ToLower(tc.Value), ""))
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package token
 
 import (
+	"strings"
+
 	"github.com/3n0ugh/allotropes/internal/config"
 	"github.com/3n0ugh/allotropes/internal/errors"
 	"github.com/golang-jwt/jwt/v4"
 )
 
+const (
+	RoleUser int8 = iota
+	RoleAdmin
+)
+
 type Claims struct {
 	Email string
 	Role  int8
@@ -23,17 +30,26 @@ func NewToken(cfg config.Config, claims Claims) (string, error) {
 	return tokenString, nil
 }
 
-func (c *Claims) ParseToken(token string) error {
+func (c *Claims) ParseToken(secret, token string) error {
+	token = strings.TrimPrefix(token, "Bearer ")
+
 	tkn, err := jwt.ParseWithClaims(token, c, func(token *jwt.Token) (interface{}, error) {
-		return token, nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secret), nil
 	})
 	if err != nil {
 		return errors.Wrap(err, "token parse")
 	}
 
 	if !tkn.Valid {
-		return errors.Wrap(err, "token validation")
+		return errors.New("token validation")
 	}
 
 	return nil
 }
+
+func (c *Claims) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
@@ -1 +1,42 @@
 package token
+
+import (
+	"testing"
+
+	"github.com/3n0ugh/allotropes/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaims_ParseToken(t *testing.T) {
+	cfg := config.Config{Application: config.Application{Secret: "secret"}}
+
+	tkn, err := NewToken(cfg, Claims{Email: "admin@movpic.com", Role: RoleAdmin})
+	assert.NoError(t, err)
+
+	testCases := map[string]struct {
+		Secret  string
+		Token   string
+		IsValid bool
+	}{
+		"should parse bearer token":             {Secret: "secret", Token: "Bearer " + tkn, IsValid: true},
+		"should parse raw token":                {Secret: "secret", Token: tkn, IsValid: true},
+		"should reject token with other secret": {Secret: "other", Token: tkn, IsValid: false},
+		"should reject malformed token":         {Secret: "secret", Token: "Bearer abc", IsValid: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var actual Claims
+			err := actual.ParseToken(tc.Secret, tc.Token)
+
+			if !tc.IsValid {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "admin@movpic.com", actual.Email)
+			assert.True(t, actual.IsAdmin())
+		})
+	}
+}
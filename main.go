@@ -3,30 +3,49 @@ package main
 import (
 	"context"
 	"log"
-	"time"
 
 	"github.com/3n0ugh/allotropes/framework/application"
 	"github.com/3n0ugh/allotropes/internal/config"
 	"github.com/3n0ugh/allotropes/internal/database"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/collection"
 	"github.com/3n0ugh/allotropes/pkg/movie"
+	"github.com/3n0ugh/allotropes/pkg/person"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy"
+	"github.com/3n0ugh/allotropes/pkg/watchlist"
 )
 
 func main() {
-	cfg := config.ReadConfig()
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cb, err := database.OpenConnectionCB(cfg)
+	middleware.SetSecret(cfg.Application.Secret)
+
+	cluster, cb, err := database.OpenConnectionCB(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pq, err := database.OpenConnectionPQ(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	movieRefController := movie.InitController(ctx, cfg, cb)
+	personController := person.InitController(ctx, cfg, cb, pq)
+	taxonomyController := taxonomy.InitController(ctx, cfg, cb, pq)
+	watchlistController := watchlist.InitController(ctx, pq, movie.NewCatalog(cb))
+	collectionController := collection.InitController(ctx, pq, movie.NewCatalog(cb))
+	movieRefController := movie.InitController(ctx, cfg, cluster, cb, pq, person.NewDirectory(cb), taxonomy.NewVocabulary(cfg, cb, pq), collection.NewMembership(pq))
 
 	a := application.App{
 		Name:           "Movpic",
 		Port:           8080,
-		Controllers:    []application.Controller{movieRefController},
+		Controllers:    []application.Controller{movieRefController, personController, taxonomyController, watchlistController, collectionController},
 		SwaggerEnabled: true,
 	}
 	a.Setup()
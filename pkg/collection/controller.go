@@ -0,0 +1,37 @@
+package collection
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/service"
+)
+
+func InitController(ctx context.Context, pq *sql.DB, movies catalog.Movies) application.Controller {
+	repo := repository.NewPostgreSQL(pq)
+
+	getCollectionsSvc := service.NewGetCollections(repo)
+	addCollectionSvc := service.NewAddCollection(repo)
+	getCollectionSvc := service.NewGetCollection(repo, movies)
+	updateCollectionSvc := service.NewUpdateCollection(repo)
+	deleteCollectionSvc := service.NewDeleteCollection(repo)
+	putCollectionMovieSvc := service.NewPutCollectionMovie(repo, movies)
+	deleteCollectionMovieSvc := service.NewDeleteCollectionMovie(repo)
+
+	return application.Controller{
+		Name:        "Collection",
+		Description: "Ordered groups of movies like a franchise",
+		Routes: []application.Route{
+			getCollectionsSvc.Route(ctx),
+			addCollectionSvc.Route(ctx),
+			getCollectionSvc.Route(ctx),
+			updateCollectionSvc.Route(ctx),
+			deleteCollectionSvc.Route(ctx),
+			putCollectionMovieSvc.Route(ctx),
+			deleteCollectionMovieSvc.Route(ctx),
+		},
+	}
+}
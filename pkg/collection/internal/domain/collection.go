@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// MaxMembers bounds the movies of a single collection.
+const MaxMembers = 100
+
+// Collection groups movies of a franchise, like The Lord of the Rings, in viewing order.
+// A movie belongs to at most one collection.
+type Collection struct {
+	ID        int       `json:"ID"`
+	Name      string    `json:"Name"`
+	Overview  string    `json:"Overview"`
+	Size      int       `json:"Size"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+func (c Collection) Validate() error {
+	if c.Name == "" || len(c.Name) > 200 {
+		return errors.New("collection name must be between 1 and 200 characters")
+	}
+	if c.Name != strings.TrimSpace(c.Name) {
+		return errors.New("collection name must not start or end with spaces")
+	}
+	if len(c.Overview) > 5000 {
+		return errors.New("collection overview must be at most 5000 characters")
+	}
+	return nil
+}
+
+// Member is a movie of a collection. Positions are contiguous from zero.
+type Member struct {
+	MovieID  int       `json:"MovieID"`
+	Position int       `json:"Position"`
+	AddedAt  time.Time `json:"AddedAt"`
+}
@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/domain"
+)
+
+// PostgreSQL stores the collections and their members. Member positions are kept contiguous
+// by shifting the neighbours inside the transaction moving a member.
+type PostgreSQL struct {
+	db *sql.DB
+}
+
+func NewPostgreSQL(db *sql.DB) *PostgreSQL {
+	return &PostgreSQL{db: db}
+}
+
+const collectionColumns = "c.id, c.name, c.overview, c.created_at, c.updated_at, " +
+	"(SELECT count(*) FROM collection_movie AS m WHERE m.collection_id = c.id)"
+
+const memberColumns = "movie_id, position, added_at"
+
+func (r *PostgreSQL) Count(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT count(*) FROM collection").Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgresql query")
+	}
+	return count, nil
+}
+
+// List returns a page of the collections ordered by name.
+func (r *PostgreSQL) List(ctx context.Context, offset, limit int) ([]domain.Collection, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+collectionColumns+" FROM collection AS c ORDER BY c.name, c.id OFFSET $1 LIMIT $2",
+		offset, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgresql query")
+	}
+	defer rows.Close()
+
+	collections := []domain.Collection{}
+
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, *c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return collections, nil
+}
+
+func (r *PostgreSQL) Get(ctx context.Context, id int) (*domain.Collection, error) {
+	return scanCollection(r.db.QueryRowContext(ctx, "SELECT "+collectionColumns+" FROM collection AS c WHERE c.id = $1", id))
+}
+
+func (r *PostgreSQL) Insert(ctx context.Context, c *domain.Collection) error {
+	err := r.db.QueryRowContext(ctx, "INSERT INTO collection (name, overview) VALUES ($1, $2) RETURNING id, created_at, updated_at",
+		c.Name, c.Overview).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+	return nil
+}
+
+func (r *PostgreSQL) Update(ctx context.Context, c domain.Collection) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE collection SET name = $2, overview = $3, updated_at = now() WHERE id = $1",
+		c.ID, c.Name, c.Overview)
+	return affected(res, err)
+}
+
+func (r *PostgreSQL) Delete(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM collection WHERE id = $1", id)
+	return affected(res, err)
+}
+
+// Members returns the members of the collection in order.
+func (r *PostgreSQL) Members(ctx context.Context, collectionID int) ([]domain.Member, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+memberColumns+" FROM collection_movie WHERE collection_id = $1 ORDER BY position",
+		collectionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgresql query")
+	}
+	defer rows.Close()
+
+	members := []domain.Member{}
+
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return members, nil
+}
+
+// PutMember adds the movie to the collection or moves it there. New members go last without
+// a position. Movies of another collection are rejected. It reports whether the member was created.
+func (r *PostgreSQL) PutMember(ctx context.Context, collectionID, movieID int, position *int) (*domain.Member, bool, error) {
+	var (
+		member  *domain.Member
+		created bool
+	)
+
+	err := r.tx(ctx, collectionID, func(tx *sql.Tx) error {
+		var other int
+		err := tx.QueryRowContext(ctx, "SELECT collection_id FROM collection_movie WHERE movie_id = $1 AND collection_id <> $2",
+			movieID, collectionID).Scan(&other)
+		if err == nil {
+			return errors.NewConflictError("movie belongs to collection "+strconv.Itoa(other), "movie in another collection")
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(err, "postgresql query")
+		}
+
+		var count int
+		if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM collection_movie WHERE collection_id = $1", collectionID).Scan(&count); err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+
+		current, err := scanMember(tx.QueryRowContext(ctx, "SELECT "+memberColumns+" FROM collection_movie "+
+			"WHERE collection_id = $1 AND movie_id = $2", collectionID, movieID))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if current == nil {
+			if count >= domain.MaxMembers {
+				return errors.NewConflictError("collection holds the maximum of 100 movies", "collection is full")
+			}
+
+			target := clamp(position, count, count)
+			if _, err := tx.ExecContext(ctx, "UPDATE collection_movie SET position = position + 1 "+
+				"WHERE collection_id = $1 AND position >= $2", collectionID, target); err != nil {
+				return errors.Wrap(err, "postgresql query")
+			}
+
+			member, err = scanMember(tx.QueryRowContext(ctx, "INSERT INTO collection_movie (collection_id, movie_id, position) "+
+				"VALUES ($1, $2, $3) RETURNING "+memberColumns, collectionID, movieID, target))
+			created = true
+			return err
+		}
+
+		target := clamp(position, current.Position, count-1)
+		switch {
+		case target < current.Position:
+			_, err = tx.ExecContext(ctx, "UPDATE collection_movie SET position = position + 1 "+
+				"WHERE collection_id = $1 AND position >= $2 AND position < $3", collectionID, target, current.Position)
+		case target > current.Position:
+			_, err = tx.ExecContext(ctx, "UPDATE collection_movie SET position = position - 1 "+
+				"WHERE collection_id = $1 AND position > $2 AND position <= $3", collectionID, current.Position, target)
+		}
+		if err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+
+		member, err = scanMember(tx.QueryRowContext(ctx, "UPDATE collection_movie SET position = $3 "+
+			"WHERE collection_id = $1 AND movie_id = $2 RETURNING "+memberColumns, collectionID, movieID, target))
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return member, created, nil
+}
+
+// DeleteMember removes the movie from the collection and closes the gap it leaves.
+func (r *PostgreSQL) DeleteMember(ctx context.Context, collectionID, movieID int) error {
+	return r.tx(ctx, collectionID, func(tx *sql.Tx) error {
+		var position int
+		err := tx.QueryRowContext(ctx, "DELETE FROM collection_movie WHERE collection_id = $1 AND movie_id = $2 RETURNING position",
+			collectionID, movieID).Scan(&position)
+		if err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE collection_movie SET position = position - 1 WHERE collection_id = $1 AND position > $2",
+			collectionID, position)
+		if err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+		return nil
+	})
+}
+
+// CollectionOf returns the collection of the movie with the member of the movie.
+func (r *PostgreSQL) CollectionOf(ctx context.Context, movieID int) (*domain.Collection, *domain.Member, error) {
+	var collectionID int
+	err := r.db.QueryRowContext(ctx, "SELECT collection_id FROM collection_movie WHERE movie_id = $1", movieID).Scan(&collectionID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "postgresql query")
+	}
+
+	c, err := r.Get(ctx, collectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := scanMember(r.db.QueryRowContext(ctx, "SELECT "+memberColumns+" FROM collection_movie "+
+		"WHERE collection_id = $1 AND movie_id = $2", collectionID, movieID))
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, m, nil
+}
+
+// RemoveMovie takes the movie out of the collection it belongs to, if any.
+func (r *PostgreSQL) RemoveMovie(ctx context.Context, movieID int) error {
+	var collectionID int
+	err := r.db.QueryRowContext(ctx, "SELECT collection_id FROM collection_movie WHERE movie_id = $1", movieID).Scan(&collectionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+
+	err = r.DeleteMember(ctx, collectionID, movieID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+// tx runs fn in a transaction holding the lock of the collection, which serializes the writes
+// reordering its members, and touches the collection after a successful write.
+func (r *PostgreSQL) tx(ctx context.Context, collectionID int, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "postgresql begin")
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM collection WHERE id = $1 FOR UPDATE", collectionID).Scan(&id)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE collection SET updated_at = now() WHERE id = $1", collectionID)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "postgresql commit")
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCollection(s scanner) (*domain.Collection, error) {
+	var c domain.Collection
+	if err := s.Scan(&c.ID, &c.Name, &c.Overview, &c.CreatedAt, &c.UpdatedAt, &c.Size); err != nil {
+		return nil, errors.Wrap(err, "row scan")
+	}
+	return &c, nil
+}
+
+func scanMember(s scanner) (*domain.Member, error) {
+	var m domain.Member
+	if err := s.Scan(&m.MovieID, &m.Position, &m.AddedAt); err != nil {
+		return nil, errors.Wrap(err, "row scan")
+	}
+	return &m, nil
+}
+
+// clamp returns the requested position bounded by max, or def without a request.
+func clamp(position *int, def, max int) int {
+	if position == nil {
+		return def
+	}
+	if *position > max {
+		return max
+	}
+	return *position
+}
+
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "postgresql rows affected")
+	}
+	if n == 0 {
+		return errors.Wrap(errors.ErrNotFound, "no such collection")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+)
+
+type AddCollection struct {
+	Repo *repository.PostgreSQL
+}
+
+type AddCollectionRequest struct {
+	Name     string `json:"name"`
+	Overview string `json:"overview"`
+}
+
+type AddCollectionResponse struct {
+	Collection domain.Collection `json:"collection"`
+}
+
+func NewAddCollection(repo *repository.PostgreSQL) *AddCollection {
+	return &AddCollection{Repo: repo}
+}
+
+func (m *AddCollection) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Add Collection",
+		Description: "Add an empty collection, movies are added to it one by one",
+		Method:      http.MethodPost,
+		Path:        "/v1/collections",
+		Headers:     map[string]string{"Location": "path of the created collection"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     AddCollectionRequest{},
+		Response:    AddCollectionResponse{},
+	}
+}
+
+func (m *AddCollection) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var req AddCollectionRequest
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "collection body unmarshal").Error())
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", "/v1/collections/"+strconv.Itoa(res.Collection.ID))
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+func (m *AddCollection) handle(ctx context.Context, r AddCollectionRequest) (*AddCollectionResponse, error) {
+	collection := domain.Collection{Name: r.Name, Overview: r.Overview}
+
+	err := collection.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = m.Repo.Insert(ctx, &collection)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &AddCollectionResponse{Collection: collection}, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/domain"
+)
+
+// HydratedMember is a member with the summary of its movie.
+type HydratedMember struct {
+	domain.Member
+	Movie catalog.Movie `json:"Movie"`
+}
+
+// hydrate looks up the movies of the members with a single batched lookup. Members whose movie
+// is in the trash are left out, they keep their position until the movie is restored or purged.
+func hydrate(ctx context.Context, movies catalog.Movies, members []domain.Member) ([]HydratedMember, error) {
+	ids := make([]int, len(members))
+	for i, m := range members {
+		ids[i] = m.MovieID
+	}
+
+	summaries, err := movies.Summaries(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "movie summaries")
+	}
+
+	hydrated := make([]HydratedMember, 0, len(members))
+	for _, m := range members {
+		if s, ok := summaries[m.MovieID]; ok {
+			hydrated = append(hydrated, HydratedMember{Member: m, Movie: s})
+		}
+	}
+	return hydrated, nil
+}
+
+func parseCollectionID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.NewBadRequestError("collection id must be integer", errors.Wrap(err, "collection id conversion").Error())
+	}
+	return id, nil
+}
+
+func parseMovieID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.NewBadRequestError("movie id must be integer", errors.Wrap(err, "movie id conversion").Error())
+	}
+	return id, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type DeleteCollection struct {
+	Repo *repository.PostgreSQL
+}
+
+type DeleteCollectionRequest struct {
+	ID int `path:"id"`
+}
+
+type DeleteCollectionResponse struct{}
+
+func NewDeleteCollection(repo *repository.PostgreSQL) *DeleteCollection {
+	return &DeleteCollection{Repo: repo}
+}
+
+func (m *DeleteCollection) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Collection",
+		Description: "Delete the collection, its movies are kept and belong to no collection afterwards",
+		Method:      http.MethodDelete,
+		Path:        "/v1/collections/{id}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteCollectionRequest{},
+		Response:    DeleteCollectionResponse{},
+	}
+}
+
+func (m *DeleteCollection) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := parseCollectionID(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, err
+		}
+
+		return m.handle(ctx, DeleteCollectionRequest{ID: id})
+	}
+}
+
+func (m *DeleteCollection) handle(ctx context.Context, r DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	err := m.Repo.Delete(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &DeleteCollectionResponse{}, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type DeleteCollectionMovie struct {
+	Repo *repository.PostgreSQL
+}
+
+type DeleteCollectionMovieRequest struct {
+	ID      int `path:"id"`
+	MovieID int `path:"movieId"`
+}
+
+type DeleteCollectionMovieResponse struct{}
+
+func NewDeleteCollectionMovie(repo *repository.PostgreSQL) *DeleteCollectionMovie {
+	return &DeleteCollectionMovie{Repo: repo}
+}
+
+func (m *DeleteCollectionMovie) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Collection Movie",
+		Description: "Remove the movie from the collection, the movies after it move up by one",
+		Method:      http.MethodDelete,
+		Path:        "/v1/collections/{id}/movies/{movieId}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteCollectionMovieRequest{},
+		Response:    DeleteCollectionMovieResponse{},
+	}
+}
+
+func (m *DeleteCollectionMovie) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := parseCollectionID(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, err
+		}
+
+		movieID, err := parseMovieID(chi.URLParam(r, "movieId"))
+		if err != nil {
+			return nil, err
+		}
+
+		return m.handle(ctx, DeleteCollectionMovieRequest{ID: id, MovieID: movieID})
+	}
+}
+
+func (m *DeleteCollectionMovie) handle(ctx context.Context, r DeleteCollectionMovieRequest) (*DeleteCollectionMovieResponse, error) {
+	err := m.Repo.DeleteMember(ctx, r.ID, r.MovieID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &DeleteCollectionMovieResponse{}, nil
+}
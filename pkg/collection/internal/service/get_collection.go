@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type GetCollection struct {
+	Repo   *repository.PostgreSQL
+	Movies catalog.Movies
+}
+
+type GetCollectionRequest struct {
+	ID int `path:"id"`
+}
+
+type GetCollectionResponse struct {
+	Collection domain.Collection `json:"collection"`
+	Movies     []HydratedMember  `json:"movies"`
+}
+
+func NewGetCollection(repo *repository.PostgreSQL, movies catalog.Movies) *GetCollection {
+	return &GetCollection{Repo: repo, Movies: movies}
+}
+
+func (m *GetCollection) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Collection",
+		Description: "Get the collection with the summaries of its movies in collection order",
+		Method:      http.MethodGet,
+		Path:        "/v1/collections/{id}",
+		Headers:     map[string]string{},
+		Handler:     m.endpoint(ctx),
+		Request:     GetCollectionRequest{},
+		Response:    GetCollectionResponse{},
+	}
+}
+
+func (m *GetCollection) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := parseCollectionID(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, err
+		}
+
+		return m.handle(ctx, GetCollectionRequest{ID: id})
+	}
+}
+
+func (m *GetCollection) handle(ctx context.Context, r GetCollectionRequest) (*GetCollectionResponse, error) {
+	collection, err := m.Repo.Get(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	members, err := m.Repo.Members(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	hydrated, err := hydrate(ctx, m.Movies, members)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &GetCollectionResponse{Collection: *collection, Movies: hydrated}, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+)
+
+type GetCollections struct {
+	Repo *repository.PostgreSQL
+}
+
+type GetCollectionsRequest struct {
+	pagination.Request
+}
+
+type GetCollectionsResponse struct {
+	TotalCount  int                 `json:"totalCount"`
+	Collections []domain.Collection `json:"collections"`
+	Pagination  pagination.Model    `json:"pagination"`
+}
+
+func NewGetCollections(repo *repository.PostgreSQL) *GetCollections {
+	return &GetCollections{Repo: repo}
+}
+
+func (m *GetCollections) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Collections",
+		Description: "Get collections by page and page size, ordered by name, with their movie counts",
+		Method:      http.MethodGet,
+		Path:        "/v1/collections",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetCollectionsRequest{},
+		Response:    GetCollectionsResponse{},
+	}
+}
+
+func (m *GetCollections) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		var req GetCollectionsRequest
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetCollections) handle(ctx context.Context, r GetCollectionsRequest) (*GetCollectionsResponse, error) {
+	total, err := m.Repo.Count(ctx)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	collections, err := m.Repo.List(ctx, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &GetCollectionsResponse{TotalCount: total, Collections: collections, Pagination: p}, nil
+}
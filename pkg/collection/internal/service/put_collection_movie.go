@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type PutCollectionMovie struct {
+	Repo   *repository.PostgreSQL
+	Movies catalog.Movies
+}
+
+// MemberInput places a movie. New members go last without a position.
+type MemberInput struct {
+	Position *int `json:"position" description:"zero based position, movies from there on move down by one"`
+}
+
+type PutCollectionMovieRequest struct {
+	ID      int         `path:"id"`
+	MovieID int         `path:"movieId"`
+	Member  MemberInput `json:"member"`
+}
+
+type PutCollectionMovieResponse struct {
+	Member HydratedMember `json:"member"`
+
+	created bool
+}
+
+func NewPutCollectionMovie(repo *repository.PostgreSQL, movies catalog.Movies) *PutCollectionMovie {
+	return &PutCollectionMovie{Repo: repo, Movies: movies}
+}
+
+func (m *PutCollectionMovie) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Put Collection Movie",
+		Description: "Add the movie to the collection or move it within, a movie belongs to one collection at most",
+		Method:      http.MethodPut,
+		Path:        "/v1/collections/{id}/movies/{movieId}",
+		Headers:     map[string]string{"Location": "path of the created member"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     PutCollectionMovieRequest{},
+		Response:    PutCollectionMovieResponse{},
+	}
+}
+
+func (m *PutCollectionMovie) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := parseCollectionID(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, err
+		}
+
+		movieID, err := parseMovieID(chi.URLParam(r, "movieId"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var input MemberInput
+
+		if len(body) > 0 {
+			err = json.Unmarshal(body, &input)
+			if err != nil {
+				return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "member body unmarshal").Error())
+			}
+		}
+
+		res, err := m.handle(ctx, PutCollectionMovieRequest{ID: id, MovieID: movieID, Member: input})
+		if err != nil {
+			return nil, err
+		}
+
+		if res.created {
+			w.Header().Set("Location", "/v1/collections/"+strconv.Itoa(id)+"/movies/"+strconv.Itoa(movieID))
+			w.WriteHeader(http.StatusCreated)
+		}
+		return res, nil
+	}
+}
+
+func (m *PutCollectionMovie) handle(ctx context.Context, r PutCollectionMovieRequest) (*PutCollectionMovieResponse, error) {
+	if r.Member.Position != nil && *r.Member.Position < 0 {
+		return nil, errors.NewBadRequestError("position must not be negative", "negative member position")
+	}
+
+	movies, err := m.Movies.Summaries(ctx, []int{r.MovieID})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "movie summaries"))
+	}
+
+	movie, ok := movies[r.MovieID]
+	if !ok {
+		return nil, errors.NewNotFoundError("movie not found", "movie "+strconv.Itoa(r.MovieID)+" is missing")
+	}
+
+	member, created, err := m.Repo.PutMember(ctx, r.ID, r.MovieID, r.Member.Position)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &PutCollectionMovieResponse{Member: HydratedMember{Member: *member, Movie: movie}, created: created}, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type UpdateCollection struct {
+	Repo *repository.PostgreSQL
+}
+
+type UpdateCollectionRequest struct {
+	ID       int    `path:"id"`
+	Name     string `json:"name"`
+	Overview string `json:"overview"`
+}
+
+type UpdateCollectionResponse struct {
+	Collection domain.Collection `json:"collection"`
+}
+
+func NewUpdateCollection(repo *repository.PostgreSQL) *UpdateCollection {
+	return &UpdateCollection{Repo: repo}
+}
+
+func (m *UpdateCollection) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Update Collection",
+		Description: "Replace the name and overview of the collection, its movies are kept",
+		Method:      http.MethodPut,
+		Path:        "/v1/collections/{id}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     UpdateCollectionRequest{},
+		Response:    UpdateCollectionResponse{},
+	}
+}
+
+func (m *UpdateCollection) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := parseCollectionID(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var req UpdateCollectionRequest
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "collection body unmarshal").Error())
+		}
+		req.ID = id
+
+		return m.handle(ctx, req)
+	}
+}
+
+func (m *UpdateCollection) handle(ctx context.Context, r UpdateCollectionRequest) (*UpdateCollectionResponse, error) {
+	collection := domain.Collection{ID: r.ID, Name: r.Name, Overview: r.Overview}
+
+	err := collection.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = m.Repo.Update(ctx, collection)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	updated, err := m.Repo.Get(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &UpdateCollectionResponse{Collection: *updated}, nil
+}
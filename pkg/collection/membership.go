@@ -0,0 +1,35 @@
+package collection
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/collection/internal/repository"
+)
+
+// Membership looks up the collections of movies for the movie package and takes purged
+// movies out of them.
+type Membership struct {
+	repo *repository.PostgreSQL
+}
+
+func NewMembership(pq *sql.DB) *Membership {
+	return &Membership{repo: repository.NewPostgreSQL(pq)}
+}
+
+func (m *Membership) OfMovie(ctx context.Context, movieID int) (*catalog.Collection, error) {
+	c, member, err := m.repo.CollectionOf(ctx, movieID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &catalog.Collection{ID: c.ID, Name: c.Name, Position: member.Position, Size: c.Size}, nil
+}
+
+func (m *Membership) RemoveMovie(ctx context.Context, movieID int) error {
+	return m.repo.RemoveMovie(ctx, movieID)
+}
@@ -0,0 +1,59 @@
+package movie
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/couchbase/gocb/v2"
+)
+
+// catalogBatchSize bounds the keys of a single summary query.
+const catalogBatchSize = 100
+
+// Catalog looks up movie summaries for other resources, one query per batch of ids.
+type Catalog struct {
+	repo *gocb.Bucket
+}
+
+func NewCatalog(repo *gocb.Bucket) *Catalog {
+	return &Catalog{repo: repo}
+}
+
+func (c *Catalog) Summaries(ctx context.Context, ids []int) (map[int]catalog.Movie, error) {
+	movies := make(map[int]catalog.Movie, len(ids))
+
+	for start := 0; start < len(ids); start += catalogBatchSize {
+		end := start + catalogBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		keys := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			keys = append(keys, strconv.Itoa(id))
+		}
+
+		rows, err := c.repo.Scope("movie").Query("SELECT m.ID, m.Title, m.ReleaseDate, m.Runtime, m.Language, m.Genres "+
+			"FROM `movie`.movie.movie AS m USE KEYS $1 WHERE m.DeletedAt IS MISSING", &gocb.QueryOptions{
+			PositionalParameters: []interface{}{keys},
+			Context:              ctx,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "couchbase query")
+		}
+
+		for rows.Next() {
+			var m catalog.Movie
+			if err := rows.Row(&m); err != nil {
+				return nil, errors.Wrap(err, "row parse")
+			}
+			movies[m.ID] = m
+		}
+		if err := rows.Err(); err != nil {
+			return nil, errors.Wrap(err, "rows")
+		}
+	}
+	return movies, nil
+}
@@ -2,19 +2,123 @@ package movie
 
 import (
 	"context"
+	"database/sql"
+	"log"
 
 	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/blob"
+	"github.com/3n0ugh/allotropes/internal/catalog"
 	"github.com/3n0ugh/allotropes/internal/config"
+	"github.com/3n0ugh/allotropes/internal/sequence"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/repository"
 	"github.com/3n0ugh/allotropes/pkg/movie/internal/service"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/similar"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/suggest"
 	"github.com/couchbase/gocb/v2"
 )
 
-func InitController(ctx context.Context, c config.Config, db *gocb.Bucket) application.Controller {
-	addMovieSvc := service.NewAddMovie(db)
-	getMoviesSvc := service.NewGetMovies(db)
-	getMovieByIDSvc := service.NewGetMovieByID(db)
-	updateMovieSvc := service.NewUpdateMovie(db)
-	deleteMovieSvc := service.NewDeleteMovie(db)
+func InitController(ctx context.Context, c config.Config, cluster *gocb.Cluster, db *gocb.Bucket, pq *sql.DB, people service.People, terms service.Terms, collections catalog.Collections) application.Controller {
+	movieIDs := sequence.New(c.Application.MovieIDSource, "movie", db, pq)
+	if err := sequence.Seed(ctx, movieIDs, db, "movie", "movie"); err != nil {
+		log.Printf("movie id seed: %s", err)
+	}
+
+	// The PostgreSQL read model follows every write, so that it stays complete while another
+	// backend is selected. The memory indexes load from Couchbase, which holds every movie.
+	var (
+		cbMovies                   = repository.NewCouchbase(db)
+		pqMovies                   = repository.NewPostgreSQL(pq)
+		movies   repository.Movies = cbMovies
+		hooks                      = service.Hooks{pqMovies}
+	)
+	if c.Application.MovieRepository == repository.PostgreSQL || c.Application.SearchBackend == repository.PostgreSQL {
+		if err := pqMovies.Backfill(ctx, cbMovies); err != nil {
+			log.Printf("movie read model backfill: %s", err)
+		}
+	}
+	if c.Application.MovieRepository == repository.PostgreSQL {
+		movies = pqMovies
+	}
+
+	var search repository.Searcher = pqMovies
+	if c.Application.SearchBackend != repository.PostgreSQL {
+		index := repository.NewMemoryIndex()
+		if err := index.Load(ctx, cbMovies); err != nil {
+			log.Printf("search index load: %s", err)
+		}
+		search, hooks = index, append(hooks, index)
+	}
+
+	suggestions := suggest.NewIndex(people)
+	if err := suggestions.Load(ctx, cbMovies); err != nil {
+		log.Printf("suggest index load: %s", err)
+	}
+	revisions := service.NewRevisions(db)
+	hooks = append(hooks, suggestions, service.NewReviewPurger(db), revisions)
+
+	neighbours := similar.NewIndex()
+	if err := neighbours.Load(ctx, cbMovies); err != nil {
+		log.Printf("similar index load: %s", err)
+	}
+	hooks = append(hooks, neighbours)
+
+	var images blob.Store = blob.NewLocal(c.Blob.Root)
+	if c.Blob.Store == blob.SourceS3 {
+		images = blob.NewS3(blob.S3Config{
+			Endpoint:  c.Blob.S3Endpoint,
+			Region:    c.Blob.S3Region,
+			Bucket:    c.Blob.S3Bucket,
+			AccessKey: c.Blob.S3AccessKey,
+			SecretKey: c.Blob.S3SecretKey,
+		})
+	}
+	hooks = append(hooks, service.NewImagePurger(images), service.NewCollectionKeeper(collections))
+
+	ratingPrior := service.NewRatingPrior(db)
+	if err := ratingPrior.Refresh(ctx); err != nil {
+		log.Printf("rating prior load: %s", err)
+	}
+
+	addMovieSvc := service.NewAddMovie(db, movieIDs, people, terms, hooks, revisions)
+	batchGetMoviesSvc := service.NewBatchGetMovies(db)
+	getMoviesSvc := service.NewGetMovies(movies, c.Application.Secret, batchGetMoviesSvc)
+	searchMoviesSvc := service.NewSearchMovies(search)
+	suggestSvc := service.NewSuggest(suggestions)
+	getSimilarMoviesSvc := service.NewGetSimilarMovies(db, neighbours, people)
+	getMovieByIDSvc := service.NewGetMovieByID(db, ratingPrior, collections)
+	updateMovieSvc := service.NewUpdateMovie(db, c.Application.RequireIfMatch, people, terms, hooks, revisions)
+	patchMovieSvc := service.NewPatchMovie(db, c.Application.RequireIfMatch, people, terms, hooks, revisions)
+	deleteMovieSvc := service.NewDeleteMovie(db, c.Application.RequireIfMatch, hooks, revisions)
+	getMovieCastSvc := service.NewGetMovieCast(db)
+	addMovieCastSvc := service.NewAddMovieCast(db, c.Application.RequireIfMatch, people, hooks, revisions)
+	updateMovieCastSvc := service.NewUpdateMovieCast(db, c.Application.RequireIfMatch, hooks, revisions)
+	deleteMovieCastSvc := service.NewDeleteMovieCast(db, c.Application.RequireIfMatch, hooks, revisions)
+	getMovieCrewSvc := service.NewGetMovieCrew(db)
+	addMovieCrewSvc := service.NewAddMovieCrew(db, c.Application.RequireIfMatch, people, hooks, revisions)
+	updateMovieCrewSvc := service.NewUpdateMovieCrew(db, c.Application.RequireIfMatch, hooks, revisions)
+	deleteMovieCrewSvc := service.NewDeleteMovieCrew(db, c.Application.RequireIfMatch, hooks, revisions)
+	uploadMovieImageSvc := service.NewUploadMovieImage(db, images, c.Blob.BaseURL, c.Application.RequireIfMatch, hooks, revisions)
+	deleteMovieImageSvc := service.NewDeleteMovieImage(db, images, c.Application.RequireIfMatch, hooks, revisions)
+	getImageSvc := service.NewGetImage(images)
+	getMovieTranslationsSvc := service.NewGetMovieTranslations(db)
+	saveMovieTranslationSvc := service.NewSaveMovieTranslation(db, c.Application.RequireIfMatch, hooks, revisions)
+	deleteMovieTranslationSvc := service.NewDeleteMovieTranslation(db, c.Application.RequireIfMatch, hooks, revisions)
+	getMovieReviewsSvc := service.NewGetMovieReviews(db, ratingPrior)
+	getMyMovieReviewSvc := service.NewGetMyMovieReview(db)
+	saveMyMovieReviewSvc := service.NewSaveMyMovieReview(cluster, db)
+	deleteMyMovieReviewSvc := service.NewDeleteMyMovieReview(cluster, db)
+	flagMovieReviewSvc := service.NewFlagMovieReview(cluster, db)
+	moderateMovieReviewSvc := service.NewModerateMovieReview(cluster, db)
+	getFlaggedReviewsSvc := service.NewGetFlaggedReviews(db)
+	batchMoviesSvc := service.NewBatchMovies(cluster, addMovieSvc, updateMovieSvc, deleteMovieSvc)
+	getTrashSvc := service.NewGetTrash(db)
+	restoreMovieSvc := service.NewRestoreMovie(db, hooks, revisions)
+	getRevisionsSvc := service.NewGetRevisions(db)
+	getRevisionSvc := service.NewGetRevision(db)
+	diffRevisionsSvc := service.NewDiffRevisions(db)
+	revertRevisionSvc := service.NewRevertRevision(db, c.Application.RequireIfMatch, people, terms, hooks, revisions)
+	purgeTrashSvc := service.NewPurgeTrash(db, c.Application.TrashRetention, hooks)
+	rewriteTermsSvc := service.NewRewriteTerms(db, terms, hooks, revisions)
 
 	return application.Controller{
 		Name:        "Movie",
@@ -22,9 +126,49 @@ func InitController(ctx context.Context, c config.Config, db *gocb.Bucket) appli
 		Routes: []application.Route{
 			addMovieSvc.Route(ctx),
 			getMoviesSvc.Route(ctx),
+			batchGetMoviesSvc.Route(ctx),
+			searchMoviesSvc.Route(ctx),
+			suggestSvc.Route(ctx),
 			getMovieByIDSvc.Route(ctx),
+			getSimilarMoviesSvc.Route(ctx),
 			updateMovieSvc.Route(ctx),
+			patchMovieSvc.Route(ctx),
 			deleteMovieSvc.Route(ctx),
+			batchMoviesSvc.Route(ctx),
+			getMovieCastSvc.Route(ctx),
+			addMovieCastSvc.Route(ctx),
+			updateMovieCastSvc.Route(ctx),
+			deleteMovieCastSvc.Route(ctx),
+			getMovieCrewSvc.Route(ctx),
+			addMovieCrewSvc.Route(ctx),
+			updateMovieCrewSvc.Route(ctx),
+			deleteMovieCrewSvc.Route(ctx),
+			uploadMovieImageSvc.Route(ctx),
+			deleteMovieImageSvc.Route(ctx),
+			getImageSvc.Route(ctx),
+			getMovieTranslationsSvc.Route(ctx),
+			saveMovieTranslationSvc.Route(ctx),
+			deleteMovieTranslationSvc.Route(ctx),
+			getMovieReviewsSvc.Route(ctx),
+			getMyMovieReviewSvc.Route(ctx),
+			saveMyMovieReviewSvc.Route(ctx),
+			deleteMyMovieReviewSvc.Route(ctx),
+			flagMovieReviewSvc.Route(ctx),
+			moderateMovieReviewSvc.Route(ctx),
+			getFlaggedReviewsSvc.Route(ctx),
+			getTrashSvc.Route(ctx),
+			restoreMovieSvc.Route(ctx),
+			getRevisionsSvc.Route(ctx),
+			getRevisionSvc.Route(ctx),
+			diffRevisionsSvc.Route(ctx),
+			revertRevisionSvc.Route(ctx),
+		},
+		Jobs: []application.Job{
+			purgeTrashSvc.Job(),
+			rewriteTermsSvc.Job(),
+			ratingPrior.Job(),
+			getSimilarMoviesSvc.Job(),
+			revisions.Job(),
 		},
 	}
 }
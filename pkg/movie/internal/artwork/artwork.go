@@ -0,0 +1,162 @@
+package artwork
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// Artwork kinds.
+const (
+	Poster   = "poster"
+	Backdrop = "backdrop"
+)
+
+const (
+	JPEG = "image/jpeg"
+	PNG  = "image/png"
+)
+
+const (
+	// MaxBytes is the size limit of an uploaded image.
+	MaxBytes = 10 << 20
+
+	// maxPixels bounds the decoded size, a small file can still decode to a huge image.
+	maxPixels = 50_000_000
+
+	jpegQuality = 85
+)
+
+// Widths are the thumbnail widths made of every artwork kind.
+var Widths = map[string][]int{
+	Poster:   {92, 185, 342, 500},
+	Backdrop: {300, 780, 1280},
+}
+
+var (
+	ErrUnsupportedType = errors.New("image must be a JPEG or PNG")
+	ErrTooManyPixels   = errors.New("image must have at most 50 megapixels")
+)
+
+// Sniff returns the content type of the image from its leading bytes, whatever the upload declared.
+func Sniff(data []byte) (string, error) {
+	switch ct := http.DetectContentType(data); ct {
+	case JPEG, PNG:
+		return ct, nil
+	}
+	return "", ErrUnsupportedType
+}
+
+// Decode decodes the image after checking its dimensions from the header.
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "image header")
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "image decode")
+	}
+	return img, nil
+}
+
+// Encode encodes the image in the content type.
+func Encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+	if contentType == PNG {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "image encode")
+	}
+	return buf.Bytes(), nil
+}
+
+// Resize scales the image down to the width keeping its aspect ratio. Every target pixel averages
+// the source pixels it covers, which keeps thin lines and avoids the aliasing of sampling. Images
+// not wider than the width are returned as they are.
+func Resize(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if width <= 0 || width >= b.Dx() {
+		return img
+	}
+
+	height := (b.Dy()*width + b.Dx()/2) / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	src := toRGBA(img)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, height, sh)
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, width, sw)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					sum[0], sum[1], sum[2], sum[3] = sum[0]+int(p[0]), sum[1]+int(p[1]), sum[2]+int(p[2]), sum[3]+int(p[3])
+				}
+			}
+
+			n := (x1 - x0) * (y1 - y0)
+			p := dst.Pix[y*dst.Stride+x*4:]
+			for c := 0; c < 4; c++ {
+				p[c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+// span returns the source pixels the target pixel i of n covers out of size, at least one.
+func span(i, n, size int) (int, int) {
+	from, to := i*size/n, (i+1)*size/n
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	if rgba, ok := img.(*image.RGBA); ok && b.Min == (image.Point{}) {
+		return rgba
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+// SizeName names the thumbnail of the width, like w185.
+func SizeName(width int) string {
+	return "w" + strconv.Itoa(width)
+}
+
+// Extension returns the file extension of the content type.
+func Extension(contentType string) string {
+	if contentType == PNG {
+		return ".png"
+	}
+	return ".jpg"
+}
@@ -0,0 +1,99 @@
+package artwork
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func checkerboard(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if (x+y)%2 == 0 {
+				img.Set(x, y, color.White)
+			} else {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+	return img
+}
+
+func TestSniff(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, checkerboard(2, 2)))
+
+	testCases := map[string]struct {
+		Given    []byte
+		Expected string
+		Err      error
+	}{
+		"should sniff png":          {Given: buf.Bytes(), Expected: PNG},
+		"should sniff jpeg":         {Given: []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), Expected: JPEG},
+		"should reject gif":         {Given: []byte("GIF89a"), Err: ErrUnsupportedType},
+		"should reject plain text":  {Given: []byte("<svg></svg>"), Err: ErrUnsupportedType},
+		"should reject empty input": {Given: nil, Err: ErrUnsupportedType},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ct, err := Sniff(tc.Given)
+
+			assert.Equal(t, tc.Err, err)
+			assert.Equal(t, tc.Expected, ct)
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	testCases := map[string]struct {
+		Width, Height, To int
+		Expected          image.Point
+	}{
+		"should keep the aspect ratio": {Width: 1000, Height: 1500, To: 185, Expected: image.Pt(185, 278)},
+		"should round the height":      {Width: 3, Height: 2, To: 2, Expected: image.Pt(2, 1)},
+		"should keep at least one row": {Width: 1000, Height: 1, To: 10, Expected: image.Pt(10, 1)},
+		"should not upscale":           {Width: 50, Height: 75, To: 92, Expected: image.Pt(50, 75)},
+		"should keep images which fit": {Width: 92, Height: 138, To: 92, Expected: image.Pt(92, 138)},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			img := Resize(checkerboard(tc.Width, tc.Height), tc.To)
+
+			assert.Equal(t, tc.Expected, img.Bounds().Size())
+		})
+	}
+}
+
+func TestResize_ShouldAverageCoveredPixels(t *testing.T) {
+	img := Resize(checkerboard(4, 4), 2).(*image.RGBA)
+
+	r, g, b, a := img.At(0, 0).RGBA()
+	assert.Equal(t, []uint32{0x8080, 0x8080, 0x8080, 0xffff}, []uint32{r, g, b, a})
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, checkerboard(3, 2)))
+
+	img, err := Decode(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, image.Pt(3, 2), img.Bounds().Size())
+
+	_, err = Decode([]byte("\x89PNG\r\n\x1a\n"))
+	assert.Error(t, err)
+}
+
+func TestDecode_ShouldRejectTooManyPixels(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 10000, 5001))))
+
+	_, err := Decode(buf.Bytes())
+	assert.Equal(t, ErrTooManyPixels, err)
+}
@@ -1,8 +1,39 @@
 package domain
 
+import (
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
 type Cast struct {
 	CastOrder int    `json:"CastOrder"`
 	Gender    string `json:"Gender"`
 	Name      string `json:"Name"`
 	PersonID  int    `json:"PersonID"`
 }
+
+func (c Cast) Validate() error {
+	if c.PersonID <= 0 {
+		return errors.New("cast person id must be positive")
+	}
+	if c.Name == "" {
+		return errors.New("cast name is required")
+	}
+	return nil
+}
+
+func (m Movie) validateCast() error {
+	seen := map[int]bool{}
+	for _, c := range m.Cast {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+
+		if seen[c.PersonID] {
+			return errors.New("person " + strconv.Itoa(c.PersonID) + " is in the cast more than once")
+		}
+		seen[c.PersonID] = true
+	}
+	return nil
+}
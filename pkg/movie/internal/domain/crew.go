@@ -1,7 +1,46 @@
 package domain
 
+import (
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// Crew is a job of a person on the movie. A person can hold several jobs, a credit is identified
+// by the person and the job.
 type Crew struct {
 	Department string `json:"Department"`
 	Job        string `json:"Job"`
 	PersonID   int    `json:"PersonID"`
 }
+
+func (c Crew) Validate() error {
+	if c.PersonID <= 0 {
+		return errors.New("crew person id must be positive")
+	}
+	if c.Job == "" {
+		return errors.New("crew job is required")
+	}
+	return nil
+}
+
+// Is reports whether the credit is the job of the person.
+func (c Crew) Is(personID int, job string) bool {
+	return c.PersonID == personID && c.Job == job
+}
+
+func (m Movie) validateCrew() error {
+	seen := map[Crew]bool{}
+	for _, c := range m.Crew {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+
+		key := Crew{PersonID: c.PersonID, Job: c.Job}
+		if seen[key] {
+			return errors.New("person " + strconv.Itoa(c.PersonID) + " is in the crew as " + c.Job + " more than once")
+		}
+		seen[key] = true
+	}
+	return nil
+}
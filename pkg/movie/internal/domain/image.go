@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Images holds the artwork of a movie. It is written by the image uploads only, movie writes
+// keep the stored images.
+type Images struct {
+	Poster   *Image `json:"Poster,omitempty"`
+	Backdrop *Image `json:"Backdrop,omitempty"`
+}
+
+// Image is an uploaded image with its thumbnails. Sizes maps thumbnail names like w185 to
+// their URLs. Prefix is where the blobs of the upload are stored.
+type Image struct {
+	ContentType string            `json:"ContentType"`
+	Width       int               `json:"Width"`
+	Height      int               `json:"Height"`
+	URL         string            `json:"URL"`
+	Sizes       map[string]string `json:"Sizes"`
+	Prefix      string            `json:"Prefix"`
+	UploadedAt  time.Time         `json:"UploadedAt"`
+}
@@ -3,19 +3,51 @@ package domain
 import "time"
 
 type Movie struct {
-	Cast        []Cast    `json:"Cast"`
-	Crew        []Crew    `json:"Crew"`
-	Genres      []string  `json:"Genres"`
-	ID          int       `json:"ID"`
-	Keywords    []string  `json:"Keywords"`
-	Language    string    `json:"Language"`
-	ReleaseDate time.Time `json:"ReleaseDate"`
-	Runtime     int       `json:"Runtime"`
-	ShortStory  string    `json:"ShortStory"`
-	Story       string    `json:"Story"`
-	Title       string    `json:"Title"`
-	Company     []string  `json:"Company"`
-	Country     []string  `json:"Country"`
+	Cast     []Cast   `json:"Cast"`
+	Crew     []Crew   `json:"Crew"`
+	Genres   []string `json:"Genres"`
+	ID       int      `json:"ID"`
+	Keywords []string `json:"Keywords"`
+	Language string   `json:"Language"`
+	// ReleaseDate follows the releases of the movie when it has any, see SyncReleaseDate.
+	ReleaseDate  time.Time              `json:"ReleaseDate"`
+	Releases     []Release              `json:"Releases"`
+	Runtime      int                    `json:"Runtime"`
+	ShortStory   string                 `json:"ShortStory"`
+	Story        string                 `json:"Story"`
+	Title        string                 `json:"Title"`
+	Company      []string               `json:"Company"`
+	Country      []string               `json:"Country"`
+	Images       Images                 `json:"Images"`
+	Translations map[string]Translation `json:"Translations,omitempty"`
+	DeletedAt    *time.Time             `json:"DeletedAt,omitempty"`
+	DeletedBy    string                 `json:"DeletedBy,omitempty"`
 }
 
-func (m Movie) Validate() error { return nil }
+// MovieFields are the fields a movie response can be projected to. HeavyMovieFields are the
+// large nested arrays and texts which list views usually leave out.
+var (
+	MovieFields = []string{
+		"Cast", "Crew", "Genres", "ID", "Keywords", "Language", "ReleaseDate", "Releases",
+		"Runtime", "ShortStory", "Story", "Title", "Company", "Country", "Images", "Translations",
+	}
+	HeavyMovieFields = []string{"Cast", "Crew", "Keywords", "Story", "Translations"}
+
+	// LocalizedMovieFields are the fields translations replace.
+	LocalizedMovieFields = []string{"Title", "ShortStory", "Story"}
+)
+
+func (m Movie) Validate() error {
+	if err := m.validateCast(); err != nil {
+		return err
+	}
+	if err := m.validateCrew(); err != nil {
+		return err
+	}
+	if err := m.validateReleases(); err != nil {
+		return err
+	}
+	return m.validateTranslations()
+}
+
+func (m Movie) IsDeleted() bool { return m.DeletedAt != nil }
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovie_Validate_Credits(t *testing.T) {
+	testCases := map[string]struct {
+		Movie Movie
+		Err   string
+	}{
+		"should accept a person holding several jobs": {
+			Movie: Movie{Crew: []Crew{{PersonID: 1, Job: "Director"}, {PersonID: 1, Job: "Writer"}}},
+		},
+		"should reject a person in the cast twice": {
+			Movie: Movie{Cast: []Cast{{PersonID: 1, Name: "Al Pacino"}, {PersonID: 1, Name: "Al Pacino"}}},
+			Err:   "person 1 is in the cast more than once",
+		},
+		"should reject a person holding a job twice": {
+			Movie: Movie{Crew: []Crew{{PersonID: 1, Job: "Director"}, {PersonID: 1, Job: "Director"}}},
+			Err:   "person 1 is in the crew as Director more than once",
+		},
+		"should reject cast without person": {
+			Movie: Movie{Cast: []Cast{{Name: "Al Pacino"}}},
+			Err:   "cast person id must be positive",
+		},
+		"should reject crew without job": {
+			Movie: Movie{Crew: []Crew{{PersonID: 1}}},
+			Err:   "crew job is required",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Movie.Validate()
+			if tc.Err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/country"
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// Release types.
+const (
+	ReleaseTheatrical = "theatrical"
+	ReleaseDigital    = "digital"
+	ReleasePhysical   = "physical"
+)
+
+var ReleaseTypes = []string{ReleaseTheatrical, ReleaseDigital, ReleasePhysical}
+
+// maxCertificationLength bounds the certifications of countries without a known rating system.
+const maxCertificationLength = 16
+
+// certifications are the ratings of the rating systems releases are checked against. Releases
+// in other countries accept any short certification.
+var certifications = map[string][]string{
+	"US": {"G", "PG", "PG-13", "R", "NC-17", "NR"},
+	"GB": {"U", "PG", "12A", "12", "15", "18", "R18"},
+	"DE": {"FSK 0", "FSK 6", "FSK 12", "FSK 16", "FSK 18"},
+	"FR": {"TP", "10", "12", "16", "18"},
+	"TR": {"Genel İzleyici", "7+", "13+", "16+", "18+"},
+	"AU": {"G", "PG", "M", "MA15+", "R18+", "X18+"},
+	"CA": {"G", "PG", "14A", "18A", "R", "A"},
+	"JP": {"G", "PG12", "R15+", "R18+"},
+	"KR": {"ALL", "12", "15", "18", "Restricted Screening"},
+	"BR": {"L", "10", "12", "14", "16", "18"},
+	"IN": {"U", "UA", "A", "S"},
+	"NL": {"AL", "6", "9", "12", "14", "16", "18"},
+}
+
+// Release is the release of a movie in a country, ISO 3166-1 alpha-2 coded, with the
+// certification it was rated with there.
+type Release struct {
+	Country       string    `json:"Country"`
+	Type          string    `json:"Type"`
+	Date          time.Time `json:"Date"`
+	Certification string    `json:"Certification,omitempty"`
+}
+
+func (r Release) Validate() error {
+	if !country.Valid(r.Country) {
+		return errors.New("release country " + r.Country + " must be an ISO 3166-1 alpha-2 code like DE")
+	}
+	if !containsString(ReleaseTypes, r.Type) {
+		return errors.New("release type must be theatrical, digital or physical")
+	}
+	if r.Date.IsZero() {
+		return errors.New("release date is required")
+	}
+
+	if r.Certification == "" {
+		return nil
+	}
+	if ratings, ok := certifications[r.Country]; ok && !containsString(ratings, r.Certification) {
+		return errors.New("certification " + r.Certification + " is not a rating of " + r.Country)
+	}
+	if len(r.Certification) > maxCertificationLength {
+		return errors.New("certification must be at most 16 characters")
+	}
+	return nil
+}
+
+// SyncReleaseDate sets ReleaseDate to the first theatrical release, or to the first release
+// of any type without one. Movies without releases keep their ReleaseDate.
+func (m *Movie) SyncReleaseDate() {
+	var first, firstTheatrical time.Time
+	for _, r := range m.Releases {
+		if first.IsZero() || r.Date.Before(first) {
+			first = r.Date
+		}
+		if r.Type == ReleaseTheatrical && (firstTheatrical.IsZero() || r.Date.Before(firstTheatrical)) {
+			firstTheatrical = r.Date
+		}
+	}
+
+	switch {
+	case !firstTheatrical.IsZero():
+		m.ReleaseDate = firstTheatrical
+	case !first.IsZero():
+		m.ReleaseDate = first
+	}
+}
+
+func (m Movie) validateReleases() error {
+	seen := map[string]bool{}
+	for _, r := range m.Releases {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+
+		key := r.Country + " " + r.Type
+		if seen[key] {
+			return errors.New("movie has more than one " + r.Type + " release in " + r.Country)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelease_Validate(t *testing.T) {
+	date := time.Date(2001, 12, 19, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		Release Release
+		Err     bool
+	}{
+		"should accept known certifications":         {Release: Release{Country: "DE", Type: ReleaseTheatrical, Date: date, Certification: "FSK 12"}},
+		"should accept releases without rating":      {Release: Release{Country: "US", Type: ReleaseDigital, Date: date}},
+		"should accept any short rating elsewhere":   {Release: Release{Country: "IT", Type: ReleasePhysical, Date: date, Certification: "VM14"}},
+		"should reject unknown countries":            {Release: Release{Country: "UK", Type: ReleaseTheatrical, Date: date}, Err: true},
+		"should reject lower case countries":         {Release: Release{Country: "de", Type: ReleaseTheatrical, Date: date}, Err: true},
+		"should reject unknown types":                {Release: Release{Country: "DE", Type: "festival", Date: date}, Err: true},
+		"should reject missing dates":                {Release: Release{Country: "DE", Type: ReleaseTheatrical}, Err: true},
+		"should reject ratings of another system":    {Release: Release{Country: "DE", Type: ReleaseTheatrical, Date: date, Certification: "PG-13"}, Err: true},
+		"should reject long ratings of other places": {Release: Release{Country: "IT", Type: ReleaseTheatrical, Date: date, Certification: "vietato ai minori di 14 anni"}, Err: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Release.Validate()
+			if tc.Err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMovie_Validate_ShouldRejectDuplicateReleases(t *testing.T) {
+	date := time.Date(2001, 12, 19, 0, 0, 0, 0, time.UTC)
+	release := Release{Country: "DE", Type: ReleaseTheatrical, Date: date}
+
+	assert.NoError(t, Movie{Releases: []Release{release, {Country: "DE", Type: ReleaseDigital, Date: date}}}.Validate())
+	assert.Error(t, Movie{Releases: []Release{release, release}}.Validate())
+}
+
+func TestMovie_SyncReleaseDate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2002, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	testCases := map[string]struct {
+		Releases []Release
+		Expected time.Time
+	}{
+		"should take the first theatrical release": {
+			Releases: []Release{
+				{Country: "US", Type: ReleaseDigital, Date: day(1)},
+				{Country: "DE", Type: ReleaseTheatrical, Date: day(10)},
+				{Country: "US", Type: ReleaseTheatrical, Date: day(5)},
+			},
+			Expected: day(5),
+		},
+		"should take the first release without theatrical ones": {
+			Releases: []Release{
+				{Country: "US", Type: ReleasePhysical, Date: day(20)},
+				{Country: "DE", Type: ReleaseDigital, Date: day(3)},
+			},
+			Expected: day(3),
+		},
+		"should keep the release date without releases": {
+			Expected: day(28),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			movie := Movie{ReleaseDate: day(28), Releases: tc.Releases}
+			movie.SyncReleaseDate()
+			assert.Equal(t, tc.Expected, movie.ReleaseDate)
+		})
+	}
+}
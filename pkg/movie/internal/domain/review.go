@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 10
+)
+
+// Review is the rating of a user for a movie with an optional text. Every user has at most one
+// review per movie. Hidden reviews keep counting in the score but their text is not listed.
+type Review struct {
+	ID        string    `json:"ID"`
+	MovieID   int       `json:"MovieID"`
+	Author    string    `json:"Author"`
+	Rating    int       `json:"Rating"`
+	Title     string    `json:"Title,omitempty"`
+	Body      string    `json:"Body,omitempty"`
+	Hidden    bool      `json:"Hidden"`
+	Flags     []Flag    `json:"Flags"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+// Flag reports a review to the moderators. A user flags a review at most once.
+type Flag struct {
+	Author    string    `json:"Author"`
+	Reason    string    `json:"Reason"`
+	CreatedAt time.Time `json:"CreatedAt"`
+}
+
+func (r Review) Validate() error {
+	if r.Rating < MinRating || r.Rating > MaxRating {
+		return errors.New("rating must be between 1 and 10")
+	}
+	if len(r.Title) > 200 {
+		return errors.New("review title must be at most 200 characters")
+	}
+	if len(r.Body) > 10000 {
+		return errors.New("review body must be at most 10000 characters")
+	}
+	if r.Body == "" && r.Title != "" {
+		return errors.New("review with a title must have a body")
+	}
+	return nil
+}
+
+// Ratings is the running total of the ratings of a movie, moved by every review write.
+type Ratings struct {
+	MovieID int `json:"MovieID"`
+	Count   int `json:"Count"`
+	Sum     int `json:"Sum"`
+}
+
+// Score summarizes the ratings of a movie. Weighted is the Bayesian average which pulls the mean
+// of rarely rated movies towards the mean of all ratings.
+type Score struct {
+	Count    int     `json:"Count"`
+	Mean     float64 `json:"Mean"`
+	Weighted float64 `json:"Weighted"`
+}
+
+// Score returns the score of the ratings given the prior mean of all ratings and the number
+// of ratings the prior weighs like.
+func (r Ratings) Score(priorMean float64, priorCount int) Score {
+	s := Score{Count: r.Count, Weighted: round(priorMean)}
+	if r.Count == 0 {
+		return s
+	}
+
+	s.Mean = round(float64(r.Sum) / float64(r.Count))
+	s.Weighted = round((float64(r.Sum) + priorMean*float64(priorCount)) / float64(r.Count+priorCount))
+	return s
+}
+
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionRevert  = "revert"
+)
+
+// Revision is an immutable snapshot of a movie taken on every write.
+type Revision struct {
+	MovieID   int       `json:"MovieID"`
+	Revision  int       `json:"Revision"`
+	Action    string    `json:"Action"`
+	Author    string    `json:"Author"`
+	Reason    string    `json:"Reason"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	Movie     *Movie    `json:"Movie,omitempty"`
+}
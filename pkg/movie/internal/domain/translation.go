@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/locale"
+)
+
+// Translation holds the texts of a movie in a locale other than its original Language.
+// Untranslated stories are served in the original language.
+type Translation struct {
+	Title      string `json:"Title"`
+	ShortStory string `json:"ShortStory,omitempty"`
+	Story      string `json:"Story,omitempty"`
+}
+
+func (t Translation) Validate() error {
+	if t.Title == "" {
+		return errors.New("translation title is required")
+	}
+	return nil
+}
+
+// Localize returns the movie with its texts in the first of the preferred locales, or their
+// fallbacks, it is available in, along with that locale. Without such a locale the movie
+// is returned in its original language.
+func (m Movie) Localize(preferred []string) (Movie, string) {
+	original, _ := locale.Canonical(m.Language)
+
+	tag, ok := locale.Lookup(preferred, func(tag string) bool {
+		_, translated := m.Translations[tag]
+		return translated || tag == original
+	})
+	if !ok || tag == original {
+		return m, m.Language
+	}
+
+	t := m.Translations[tag]
+	m.Title = t.Title
+	if t.ShortStory != "" {
+		m.ShortStory = t.ShortStory
+	}
+	if t.Story != "" {
+		m.Story = t.Story
+	}
+	return m, tag
+}
+
+func (m Movie) validateTranslations() error {
+	original, _ := locale.Canonical(m.Language)
+
+	for tag, t := range m.Translations {
+		if canonical, err := locale.Canonical(tag); err != nil || canonical != tag {
+			return errors.New("translation locale " + tag + " must be a canonical language tag like pt-BR")
+		}
+		if tag == original {
+			return errors.New("translation locale " + tag + " is the original language of the movie")
+		}
+		if err := t.Validate(); err != nil {
+			return errors.Wrap(err, tag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+type couchbase struct {
+	repo *gocb.Bucket
+}
+
+// NewCouchbase returns the movie repository querying the `movie`.movie.movie collection with N1QL.
+func NewCouchbase(repo *gocb.Bucket) Movies {
+	return &couchbase{repo: repo}
+}
+
+func (r *couchbase) List(ctx context.Context, q Query) ([]domain.Movie, error) {
+	b := newBuilder(n1ql{}, q)
+
+	page, err := b.page(q)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + n1ql{}.project(q.fields()) + " FROM `movie`.movie.movie" + b.whereClause() + page
+
+	rows, err := r.repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: b.params,
+		Context:              ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	movies := []domain.Movie{}
+
+	for rows.Next() {
+		var movie domain.Movie
+
+		err := rows.Row(&movie)
+		if err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		movies = append(movies, movie)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return movies, nil
+}
+
+func (r *couchbase) Count(ctx context.Context, q Query) (int, error) {
+	b := newBuilder(n1ql{}, q)
+
+	query := "SELECT RAW COUNT(*) FROM `movie`.movie.movie" + b.whereClause()
+
+	res, err := r.repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: b.params,
+		Context:              ctx,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+
+	var count int
+	if err := res.One(&count); err != nil {
+		return 0, errors.Wrap(err, "row parse")
+	}
+	return count, nil
+}
+
+func (r *couchbase) Facet(ctx context.Context, q Query, f Facet) ([]FacetCount, error) {
+	b := newBuilder(n1ql{}, Query{Filter: q.Filter})
+
+	rows, err := r.repo.Scope("movie").Query(b.facet("`movie`.movie.movie", f), &gocb.QueryOptions{
+		PositionalParameters: b.params,
+		Context:              ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	counts := []FacetCount{}
+
+	for rows.Next() {
+		var row struct {
+			Value string `json:"facet_value"`
+			Count int    `json:"facet_count"`
+		}
+
+		if err := rows.Row(&row); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		counts = append(counts, FacetCount{Value: row.Value, Count: row.Count})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return counts, nil
+}
+
+type n1ql struct{}
+
+func (n1ql) field(name string) string { return "movie." + quote(name) }
+func (n1ql) param(n int) string       { return "$" + strconv.Itoa(n) }
+func (n1ql) value(v any) any          { return v }
+func (d n1ql) project(fields []string) string {
+	if fields == nil {
+		return "RAW movie"
+	}
+
+	list := make([]string, 0, len(fields))
+	for _, f := range fields {
+		list = append(list, d.field(f))
+	}
+	return strings.Join(list, ", ")
+}
+
+func (d n1ql) facet(f Facet) (string, string) {
+	switch {
+	case f == FacetDecade:
+		return "", "TOSTRING(FLOOR(DATE_PART_STR(" + d.field("ReleaseDate") + ", 'year') / 10) * 10)"
+	case f.isArray():
+		return " UNNEST " + d.field(string(f)) + " AS facet", "facet"
+	}
+	return "", d.field(string(f))
+}
+
+func (n1ql) timestamp(expr string) string { return "STR_TO_MILLIS(" + expr + ")" }
+
+func (n1ql) notDeleted() string { return "movie.DeletedAt IS NOT VALUED" }
+
+func (d n1ql) condition(c Condition, bind func(v any) string) string {
+	if c.Op != Contains {
+		field, value := operands(d, d.field(c.Field), c, bind)
+		return field + " " + string(c.Op) + " " + value
+	}
+
+	elem := "v"
+	if c.Elem != "" {
+		elem += "." + quote(c.Elem)
+	}
+	return "ANY v IN " + d.field(c.Field) + " SATISFIES " + elem + " = " + bind(c.Value) + " END"
+}
+
+func (d n1ql) any(a Any, bind func(v any) string) string {
+	terms := make([]string, 0, len(a.Conditions))
+	for _, c := range a.Conditions {
+		field, value := operands(d, "v."+quote(c.Field), c, bind)
+		terms = append(terms, field+" "+string(c.Op)+" "+value)
+	}
+
+	where := strings.Join(terms, " AND ")
+	if where == "" {
+		where = "TRUE"
+	}
+	return "ANY v IN " + d.field(a.Field) + " SATISFIES " + where + " END"
+}
@@ -0,0 +1,45 @@
+package repository
+
+import "time"
+
+// Facet is an aggregation of the listing by a movie field.
+type Facet string
+
+const (
+	FacetGenres   Facet = "Genres"
+	FacetLanguage Facet = "Language"
+	FacetCountry  Facet = "Country"
+	FacetCompany  Facet = "Company"
+	// FacetDecade aggregates by the decade of the ReleaseDate, e.g. "1990".
+	FacetDecade Facet = "Decade"
+)
+
+// Facets are the supported facets, array fields count every element.
+var Facets = []Facet{FacetGenres, FacetLanguage, FacetCountry, FacetCompany, FacetDecade}
+
+// FacetLimit is the number of most frequent values returned per facet.
+const FacetLimit = 50
+
+// FacetCount is the number of movies having a facet value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+func (f Facet) isArray() bool {
+	return f == FacetGenres || f == FacetCountry || f == FacetCompany
+}
+
+// facet returns the GROUP BY statement counting the movies of the query by the facet values,
+// leaving out empty values and movies without a release date.
+func (b *builder) facet(from string, f Facet) string {
+	join, value := b.d.facet(f)
+
+	b.where = append(b.where, value+" IS NOT NULL", value+" <> ''")
+	if f == FacetDecade {
+		b.where = append(b.where, b.d.field("ReleaseDate")+" > "+b.bind(time.Time{}.Format(time.RFC3339)))
+	}
+
+	return "SELECT " + value + " AS facet_value, COUNT(*) AS facet_count FROM " + from + join + b.whereClause() +
+		" GROUP BY " + value + " ORDER BY facet_count DESC, facet_value ASC LIMIT " + b.bindRaw(FacetLimit)
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Expr is a node of the backend neutral filter AST.
+type Expr interface {
+	expr()
+}
+
+// And matches when every expression matches. An empty And matches everything.
+type And []Expr
+
+// Or matches when any expression matches.
+type Or []Expr
+
+type Op string
+
+const (
+	Eq       Op = "="
+	Gt       Op = ">"
+	Gte      Op = ">="
+	Lt       Op = "<"
+	Lte      Op = "<="
+	Contains Op = "contains"
+)
+
+// Condition compares a top level movie field with a value. Contains matches array fields
+// having an element equal to the value, or, when Elem is set, an object element whose
+// Elem field equals the value. A time.Time value compares the RFC3339 dates of the field
+// chronologically, whatever their offsets.
+type Condition struct {
+	Field string
+	Elem  string
+	Op    Op
+	Value any
+}
+
+// Any matches array fields having an object element which satisfies every condition, the
+// conditions comparing members of the element. Contains is not supported within elements.
+type Any struct {
+	Field      string
+	Conditions []Condition
+}
+
+func (And) expr()       {}
+func (Or) expr()        {}
+func (Condition) expr() {}
+func (Any) expr()       {}
+
+// filter compiles the expression into a condition of the dialect.
+func (b *builder) filter(e Expr) string {
+	switch e := e.(type) {
+	case And:
+		return b.join(e, " AND ")
+	case Or:
+		return b.join(e, " OR ")
+	case Condition:
+		return b.d.condition(e, b.bind)
+	case Any:
+		return b.d.any(e, b.bind)
+	}
+	return ""
+}
+
+func (b *builder) join(exprs []Expr, sep string) string {
+	terms := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		if t := b.filter(e); t != "" {
+			terms = append(terms, t)
+		}
+	}
+
+	if len(terms) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(terms, sep) + ")"
+}
+
+// operands returns the compared field and the bound value of the condition, as timestamps
+// when the value is a time.Time.
+func operands(d dialect, field string, c Condition, bind func(v any) string) (string, string) {
+	value := bind(c.Value)
+	if _, ok := c.Value.(time.Time); ok {
+		return d.timestamp(field), d.timestamp(value)
+	}
+	return field, value
+}
+
+// whereFilter adds the filter of the query.
+func (b *builder) whereFilter(q Query) *builder {
+	if q.Filter != nil {
+		if t := b.filter(q.Filter); t != "" {
+			b.where = append(b.where, t)
+		}
+	}
+	return b
+}
+
+// Match evaluates the expression against a movie document decoded from JSON, for backends
+// filtering in memory. Empty expressions match, the same way they compile to no condition.
+func Match(e Expr, doc map[string]any) bool {
+	switch e := e.(type) {
+	case And:
+		for _, x := range e {
+			if !Match(x, doc) {
+				return false
+			}
+		}
+	case Or:
+		if len(e) == 0 {
+			return true
+		}
+		for _, x := range e {
+			if Match(x, doc) {
+				return true
+			}
+		}
+		return false
+	case Condition:
+		return e.match(doc)
+	case Any:
+		elems, _ := doc[e.Field].([]any)
+		for _, elem := range elems {
+			obj, ok := elem.(map[string]any)
+			if ok && Match(conditions(e.Conditions), obj) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func conditions(cs []Condition) And {
+	and := make(And, len(cs))
+	for i, c := range cs {
+		and[i] = c
+	}
+	return and
+}
+
+func (c Condition) match(doc map[string]any) bool {
+	want := jsonValue(c.Value)
+
+	if c.Op == Contains {
+		elems, _ := doc[c.Field].([]any)
+		for _, elem := range elems {
+			if c.Elem != "" {
+				obj, ok := elem.(map[string]any)
+				if !ok {
+					continue
+				}
+				elem = obj[c.Elem]
+			}
+			if n, ok := compare(elem, want); ok && n == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	n, ok := compare(doc[c.Field], want)
+	if t, isTime := c.Value.(time.Time); isTime {
+		n, ok = compareTime(doc[c.Field], t)
+	}
+	if !ok {
+		return false
+	}
+
+	switch c.Op {
+	case Eq:
+		return n == 0
+	case Gt:
+		return n > 0
+	case Gte:
+		return n >= 0
+	case Lt:
+		return n < 0
+	case Lte:
+		return n <= 0
+	}
+	return false
+}
+
+// compare orders numbers numerically and strings lexically. Other values are not comparable.
+func compare(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			}
+			return 0, true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	}
+	return 0, false
+}
+
+// compareTime orders an RFC3339 date chronologically against the time. Other values are
+// not comparable.
+func compareTime(a any, b time.Time) (int, bool) {
+	s, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, false
+	}
+
+	switch {
+	case t.Before(b):
+		return -1, true
+	case t.After(b):
+		return 1, true
+	}
+	return 0, true
+}
+
+// jsonValue converts a value to the form it has in a decoded document.
+func jsonValue(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/lib/pq"
+)
+
+// PostgreSQLMovies is the movie repository querying the PostgreSQL movie table, a read model
+// holding the movie documents as jsonb. It is kept current as a movie write hook whatever the
+// selected backends, and backfilled from Couchbase when it gets selected.
+type PostgreSQLMovies struct {
+	db *sql.DB
+}
+
+func NewPostgreSQL(db *sql.DB) *PostgreSQLMovies {
+	return &PostgreSQLMovies{db: db}
+}
+
+func (r *PostgreSQLMovies) List(ctx context.Context, q Query) ([]domain.Movie, error) {
+	b := newBuilder(postgresql{}, q)
+
+	page, err := b.page(q)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+postgresql{}.project(q.fields())+" FROM movie"+b.whereClause()+page, b.params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgresql query")
+	}
+	defer rows.Close()
+
+	movies := []domain.Movie{}
+
+	for rows.Next() {
+		var doc []byte
+		if err := rows.Scan(&doc); err != nil {
+			return nil, errors.Wrap(err, "row scan")
+		}
+
+		var movie domain.Movie
+		if err := json.Unmarshal(doc, &movie); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		movies = append(movies, movie)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return movies, nil
+}
+
+func (r *PostgreSQLMovies) Count(ctx context.Context, q Query) (int, error) {
+	b := newBuilder(postgresql{}, q)
+
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT count(*) FROM movie"+b.whereClause(), b.params...).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgresql query")
+	}
+	return count, nil
+}
+
+func (r *PostgreSQLMovies) Facet(ctx context.Context, q Query, f Facet) ([]FacetCount, error) {
+	b := newBuilder(postgresql{}, Query{Filter: q.Filter})
+
+	rows, err := r.db.QueryContext(ctx, b.facet("movie", f), b.params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgresql query")
+	}
+	defer rows.Close()
+
+	counts := []FacetCount{}
+
+	for rows.Next() {
+		var c FacetCount
+		if err := rows.Scan(&c.Value, &c.Count); err != nil {
+			return nil, errors.Wrap(err, "row scan")
+		}
+		counts = append(counts, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return counts, nil
+}
+
+// Backfill copies every movie of the source into the read model and removes the movies the source
+// no longer lists, so that the read model is complete when it gets selected after running without it.
+func (r *PostgreSQLMovies) Backfill(ctx context.Context, source Movies) error {
+	ids := []int64{}
+	err := Each(ctx, source, func(movie domain.Movie) error {
+		ids = append(ids, int64(movie.ID))
+		return r.MovieSaved(ctx, movie)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, "DELETE FROM movie WHERE NOT (id = ANY($1))", pq.Array(ids))
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+	return nil
+}
+
+// MovieSaved upserts the movie document into the read model.
+func (r *PostgreSQLMovies) MovieSaved(ctx context.Context, movie domain.Movie) error {
+	doc, err := json.Marshal(movie)
+	if err != nil {
+		return errors.Wrap(err, "movie marshal")
+	}
+
+	_, err = r.db.ExecContext(ctx, "INSERT INTO movie (id, doc) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET doc = EXCLUDED.doc", movie.ID, doc)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+	return nil
+}
+
+// MoviePurged removes the movie document from the read model.
+func (r *PostgreSQLMovies) MoviePurged(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM movie WHERE id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+	return nil
+}
+
+type postgresql struct{}
+
+// field compares jsonb values, which orders numbers numerically and strings lexically
+// the same way the N1QL collation does.
+func (postgresql) field(name string) string { return "(doc -> " + pqString(name) + ")" }
+func (postgresql) param(n int) string       { return "$" + strconv.Itoa(n) + "::jsonb" }
+func (d postgresql) project(fields []string) string {
+	if fields == nil {
+		return "doc"
+	}
+
+	pairs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		pairs = append(pairs, pqString(f)+", "+d.field(f))
+	}
+	return "jsonb_build_object(" + strings.Join(pairs, ", ") + ")"
+}
+
+// facet unnests array facets with a lateral join, documents holding null instead of an
+// array simply have no element.
+func (d postgresql) facet(f Facet) (string, string) {
+	switch {
+	case f == FacetDecade:
+		return "", "(floor(extract(year FROM (doc ->> 'ReleaseDate')::timestamptz) / 10) * 10)::int::text"
+	case f.isArray():
+		elems := "CASE WHEN jsonb_typeof(" + d.field(string(f)) + ") = 'array' THEN " + d.field(string(f)) + " END"
+		return ", jsonb_array_elements_text(" + elems + ") AS facet(value)", "facet.value"
+	}
+	return "", "doc ->> " + pqString(string(f))
+}
+
+func (postgresql) timestamp(expr string) string { return "(" + expr + " #>> '{}')::timestamptz" }
+
+func (postgresql) notDeleted() string { return "doc -> 'DeletedAt' IS NULL" }
+
+// condition compiles Contains to containment of the whole document, which the GIN index
+// on doc serves for both array elements and object elements having the given member.
+func (d postgresql) condition(c Condition, bind func(v any) string) string {
+	if c.Op != Contains {
+		field, value := operands(d, d.field(c.Field), c, bind)
+		return field + " " + string(c.Op) + " " + value
+	}
+
+	elem := c.Value
+	if c.Elem != "" {
+		elem = map[string]any{c.Elem: c.Value}
+	}
+	return "doc @> " + bind(map[string]any{c.Field: []any{elem}})
+}
+
+// any tests the elements of the array, fields holding null instead of an array have none.
+func (d postgresql) any(a Any, bind func(v any) string) string {
+	terms := make([]string, 0, len(a.Conditions))
+	for _, c := range a.Conditions {
+		field, value := operands(d, "(elem.v -> "+pqString(c.Field)+")", c, bind)
+		terms = append(terms, field+" "+string(c.Op)+" "+value)
+	}
+
+	where := strings.Join(terms, " AND ")
+	if where == "" {
+		where = "TRUE"
+	}
+
+	elems := "CASE WHEN jsonb_typeof(" + d.field(a.Field) + ") = 'array' THEN " + d.field(a.Field) + " END"
+	return "EXISTS (SELECT 1 FROM jsonb_array_elements(" + elems + ") AS elem(v) WHERE " + where + ")"
+}
+
+func (postgresql) value(v any) any {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func pqString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// dialect is the part of a query language that differs between the backends.
+// Both N1QL and PostgreSQL use $n positional parameters.
+type dialect interface {
+	// field returns the expression of a top level movie field.
+	field(name string) string
+	// param returns the placeholder of the nth parameter compared against a field.
+	param(n int) string
+	// value converts a value compared against a field to the form the placeholder expects.
+	value(v any) any
+	// project returns the select list of the fields, the whole document when nil.
+	project(fields []string) string
+	// timestamp converts an expression holding an RFC3339 date to a chronologically ordered value.
+	timestamp(expr string) string
+	// notDeleted returns the condition excluding soft deleted movies.
+	notDeleted() string
+	// facet returns the join unnesting an array facet and the expression of the facet value.
+	facet(f Facet) (join, value string)
+	// condition compiles a filter condition, binding its values with bind.
+	condition(c Condition, bind func(v any) string) string
+	// any compiles a condition on the elements of an array field, binding its values with bind.
+	any(a Any, bind func(v any) string) string
+}
+
+// builder accumulates the conditions and the parameters of a query. Values are always
+// bound as parameters, never concatenated into the statement.
+type builder struct {
+	d      dialect
+	where  []string
+	params []any
+}
+
+func newBuilder(d dialect, q Query) *builder {
+	b := &builder{d: d, where: []string{d.notDeleted()}}
+	return b.whereFilter(q)
+}
+
+// bind adds a value compared against a field.
+func (b *builder) bind(v any) string {
+	b.params = append(b.params, b.d.value(v))
+	return b.d.param(len(b.params))
+}
+
+// bindRaw adds a value used as is, like a limit.
+func (b *builder) bindRaw(v any) string {
+	b.params = append(b.params, v)
+	return "$" + strconv.Itoa(len(b.params))
+}
+
+func (b *builder) whereClause() string {
+	return " WHERE " + strings.Join(b.where, " AND ")
+}
+
+// keyset adds the condition selecting the movies sorted after the key:
+// (s1 > v1) OR (s1 = v1 AND s2 > v2) OR ... with the comparison flipped for descending fields.
+func (b *builder) keyset(sorts []Sort, after Key) error {
+	values := append(append([]any{}, after.Values...), after.ID)
+	if len(values) != len(sorts) {
+		return errors.New("cursor does not match the sort")
+	}
+
+	var or []string
+	for i, s := range sorts {
+		and := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			and = append(and, b.d.field(sorts[j].Field)+" = "+b.bind(values[j]))
+		}
+
+		op := " > "
+		if s.Desc {
+			op = " < "
+		}
+		and = append(and, b.d.field(s.Field)+op+b.bind(values[i]))
+
+		or = append(or, "("+strings.Join(and, " AND ")+")")
+	}
+
+	b.where = append(b.where, "("+strings.Join(or, " OR ")+")")
+	return nil
+}
+
+func (b *builder) orderBy(sorts []Sort) string {
+	fields := make([]string, 0, len(sorts))
+	for _, s := range sorts {
+		if s.Desc {
+			fields = append(fields, b.d.field(s.Field)+" DESC")
+		} else {
+			fields = append(fields, b.d.field(s.Field)+" ASC")
+		}
+	}
+	return " ORDER BY " + strings.Join(fields, ", ")
+}
+
+// page adds the keyset condition or the offset of the query and returns the
+// ORDER BY, LIMIT and OFFSET clauses.
+func (b *builder) page(q Query) (string, error) {
+	sorts := q.sorts()
+
+	if q.After != nil {
+		if err := b.keyset(sorts, *q.After); err != nil {
+			return "", err
+		}
+		q.Offset = 0
+	}
+
+	clause := b.orderBy(sorts) + " LIMIT " + b.bindRaw(q.Limit)
+	if q.Offset > 0 {
+		clause += " OFFSET " + b.bindRaw(q.Offset)
+	}
+	return clause, nil
+}
+
+func quote(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
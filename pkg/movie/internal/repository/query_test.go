@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Page(t *testing.T) {
+	q := Query{
+		Sort:  []Sort{{Field: "ReleaseDate", Desc: true}},
+		After: &Key{Values: []any{"2001-12-19T00:00:00Z"}, ID: 120},
+		Limit: 20,
+	}
+
+	testCases := map[string]struct {
+		Dialect        dialect
+		ExpectedWhere  string
+		ExpectedPage   string
+		ExpectedParams []any
+	}{
+		"should compile keyset to n1ql": {
+			Dialect: n1ql{},
+			ExpectedWhere: " WHERE movie.DeletedAt IS NOT VALUED AND " +
+				"((movie.`ReleaseDate` < $1) OR (movie.`ReleaseDate` = $2 AND movie.`ID` > $3))",
+			ExpectedPage:   " ORDER BY movie.`ReleaseDate` DESC, movie.`ID` ASC LIMIT $4",
+			ExpectedParams: []any{"2001-12-19T00:00:00Z", "2001-12-19T00:00:00Z", 120, 20},
+		},
+		"should compile keyset to sql": {
+			Dialect: postgresql{},
+			ExpectedWhere: " WHERE doc -> 'DeletedAt' IS NULL AND " +
+				"(((doc -> 'ReleaseDate') < $1::jsonb) OR ((doc -> 'ReleaseDate') = $2::jsonb AND (doc -> 'ID') > $3::jsonb))",
+			ExpectedPage:   " ORDER BY (doc -> 'ReleaseDate') DESC, (doc -> 'ID') ASC LIMIT $4",
+			ExpectedParams: []any{`"2001-12-19T00:00:00Z"`, `"2001-12-19T00:00:00Z"`, "120", 20},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := newBuilder(tc.Dialect, Query{})
+
+			page, err := b.page(q)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedWhere, b.whereClause())
+			assert.Equal(t, tc.ExpectedPage, page)
+			assert.Equal(t, tc.ExpectedParams, b.params)
+		})
+	}
+}
+
+func TestBuilder_Page_ShouldUseOffsetWithoutCursor(t *testing.T) {
+	b := newBuilder(n1ql{}, Query{})
+
+	page, err := b.page(Query{Offset: 40, Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, " ORDER BY movie.`ID` ASC LIMIT $1 OFFSET $2", page)
+	assert.Equal(t, []any{20, 40}, b.params)
+}
+
+func TestBuilder_Page_ShouldRejectKeyOfAnotherSort(t *testing.T) {
+	b := newBuilder(n1ql{}, Query{})
+
+	_, err := b.page(Query{Sort: []Sort{{Field: "Title"}}, After: &Key{ID: 1}, Limit: 20})
+
+	assert.Error(t, err)
+}
+
+func TestQuery_KeyOf(t *testing.T) {
+	q := Query{Sort: []Sort{{Field: "Title"}, {Field: "Runtime", Desc: true}}}
+
+	actual, err := q.KeyOf(domain.Movie{ID: 7, Title: "Heat", Runtime: 170})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Key{Values: []any{"Heat", float64(170)}, ID: 7}, actual)
+	assert.Equal(t, "Title,-Runtime", q.SortString())
+}
+
+func TestBuilder_Filter(t *testing.T) {
+	q := Query{Filter: And{
+		Or{
+			Condition{Field: "Genres", Op: Contains, Value: "Drama"},
+			Condition{Field: "Genres", Op: Contains, Value: "Crime"},
+		},
+		Or{},
+		Condition{Field: "Cast", Elem: "PersonID", Op: Contains, Value: 7},
+		Condition{Field: "Runtime", Op: Gte, Value: 90},
+	}}
+
+	testCases := map[string]struct {
+		Dialect        dialect
+		ExpectedWhere  string
+		ExpectedParams []any
+	}{
+		"should compile filter to n1ql": {
+			Dialect: n1ql{},
+			ExpectedWhere: " WHERE movie.DeletedAt IS NOT VALUED AND " +
+				"((ANY v IN movie.`Genres` SATISFIES v = $1 END OR ANY v IN movie.`Genres` SATISFIES v = $2 END) AND " +
+				"ANY v IN movie.`Cast` SATISFIES v.`PersonID` = $3 END AND movie.`Runtime` >= $4)",
+			ExpectedParams: []any{"Drama", "Crime", 7, 90},
+		},
+		"should compile filter to sql": {
+			Dialect: postgresql{},
+			ExpectedWhere: " WHERE doc -> 'DeletedAt' IS NULL AND " +
+				"((doc @> $1::jsonb OR doc @> $2::jsonb) AND doc @> $3::jsonb AND (doc -> 'Runtime') >= $4::jsonb)",
+			ExpectedParams: []any{`{"Genres":["Drama"]}`, `{"Genres":["Crime"]}`, `{"Cast":[{"PersonID":7}]}`, "90"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := newBuilder(tc.Dialect, q)
+
+			assert.Equal(t, tc.ExpectedWhere, b.whereClause())
+			assert.Equal(t, tc.ExpectedParams, b.params)
+		})
+	}
+}
+
+func TestBuilder_Filter_ShouldCompileAny(t *testing.T) {
+	date := time.Date(2001, 12, 19, 0, 0, 0, 0, time.UTC)
+	q := Query{Filter: Any{Field: "Releases", Conditions: []Condition{
+		{Field: "Country", Op: Eq, Value: "DE"},
+		{Field: "Date", Op: Gte, Value: date},
+	}}}
+
+	testCases := map[string]struct {
+		Dialect        dialect
+		ExpectedWhere  string
+		ExpectedParams []any
+	}{
+		"should compile any to n1ql": {
+			Dialect: n1ql{},
+			ExpectedWhere: " WHERE movie.DeletedAt IS NOT VALUED AND " +
+				"ANY v IN movie.`Releases` SATISFIES v.`Country` = $1 AND STR_TO_MILLIS(v.`Date`) >= STR_TO_MILLIS($2) END",
+			ExpectedParams: []any{"DE", date},
+		},
+		"should compile any to sql": {
+			Dialect: postgresql{},
+			ExpectedWhere: " WHERE doc -> 'DeletedAt' IS NULL AND " +
+				"EXISTS (SELECT 1 FROM jsonb_array_elements(CASE WHEN jsonb_typeof((doc -> 'Releases')) = 'array' THEN (doc -> 'Releases') END)" +
+				" AS elem(v) WHERE (elem.v -> 'Country') = $1::jsonb AND ((elem.v -> 'Date') #>> '{}')::timestamptz >= ($2::jsonb #>> '{}')::timestamptz)",
+			ExpectedParams: []any{`"DE"`, `"2001-12-19T00:00:00Z"`},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := newBuilder(tc.Dialect, q)
+
+			assert.Equal(t, tc.ExpectedWhere, b.whereClause())
+			assert.Equal(t, tc.ExpectedParams, b.params)
+		})
+	}
+}
+
+func TestMatch_Any(t *testing.T) {
+	doc := map[string]any{"Releases": []any{
+		map[string]any{"Country": "DE", "Type": "theatrical", "Date": "2002-01-01T00:30:00+01:00"},
+		map[string]any{"Country": "US", "Type": "theatrical", "Date": "2001-12-19T00:00:00Z"},
+	}}
+
+	inDE := func(op Op, date time.Time) Expr {
+		return Any{Field: "Releases", Conditions: []Condition{
+			{Field: "Country", Op: Eq, Value: "DE"},
+			{Field: "Date", Op: op, Value: date},
+		}}
+	}
+
+	assert.True(t, Match(inDE(Gte, time.Date(2001, 12, 31, 0, 0, 0, 0, time.UTC)), doc))
+	assert.False(t, Match(inDE(Gte, time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)), doc))
+	assert.True(t, Match(inDE(Lt, time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)), doc))
+	assert.True(t, Match(Any{Field: "Releases"}, doc))
+	assert.False(t, Match(Any{Field: "Releases"}, map[string]any{"Releases": nil}))
+}
+
+func TestQuery_Project(t *testing.T) {
+	q := Query{Fields: []string{"Title"}, Sort: []Sort{{Field: "ReleaseDate", Desc: true}}}
+
+	assert.Equal(t, "movie.`Title`, movie.`ReleaseDate`, movie.`ID`", n1ql{}.project(q.fields()))
+	assert.Equal(t, "jsonb_build_object('Title', (doc -> 'Title'), 'ReleaseDate', (doc -> 'ReleaseDate'), 'ID', (doc -> 'ID'))",
+		postgresql{}.project(q.fields()))
+	assert.Equal(t, "RAW movie", n1ql{}.project(Query{}.fields()))
+}
+
+func TestBuilder_Facet(t *testing.T) {
+	q := Query{Filter: And{Condition{Field: "Language", Op: Eq, Value: "en"}}}
+
+	testCases := map[string]struct {
+		Dialect        dialect
+		From           string
+		Facet          Facet
+		Expected       string
+		ExpectedParams []any
+	}{
+		"should unnest array facet in n1ql": {
+			Dialect: n1ql{},
+			From:    "`movie`.movie.movie",
+			Facet:   FacetGenres,
+			Expected: "SELECT facet AS facet_value, COUNT(*) AS facet_count FROM `movie`.movie.movie UNNEST movie.`Genres` AS facet" +
+				" WHERE movie.DeletedAt IS NOT VALUED AND (movie.`Language` = $1) AND facet IS NOT NULL AND facet <> ''" +
+				" GROUP BY facet ORDER BY facet_count DESC, facet_value ASC LIMIT $2",
+			ExpectedParams: []any{"en", FacetLimit},
+		},
+		"should group by decade in sql": {
+			Dialect: postgresql{},
+			From:    "movie",
+			Facet:   FacetDecade,
+			Expected: "SELECT (floor(extract(year FROM (doc ->> 'ReleaseDate')::timestamptz) / 10) * 10)::int::text AS facet_value, COUNT(*) AS facet_count FROM movie" +
+				" WHERE doc -> 'DeletedAt' IS NULL AND ((doc -> 'Language') = $1::jsonb)" +
+				" AND (floor(extract(year FROM (doc ->> 'ReleaseDate')::timestamptz) / 10) * 10)::int::text IS NOT NULL" +
+				" AND (floor(extract(year FROM (doc ->> 'ReleaseDate')::timestamptz) / 10) * 10)::int::text <> ''" +
+				" AND (doc -> 'ReleaseDate') > $2::jsonb" +
+				" GROUP BY (floor(extract(year FROM (doc ->> 'ReleaseDate')::timestamptz) / 10) * 10)::int::text" +
+				" ORDER BY facet_count DESC, facet_value ASC LIMIT $3",
+			ExpectedParams: []any{`"en"`, `"0001-01-01T00:00:00Z"`, FacetLimit},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := newBuilder(tc.Dialect, q)
+
+			assert.Equal(t, tc.Expected, b.facet(tc.From, tc.Facet))
+			assert.Equal(t, tc.ExpectedParams, b.params)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+)
+
+const (
+	Couchbase  = "couchbase"
+	PostgreSQL = "postgresql"
+)
+
+// Movies lists movies. Implementations compile the backend neutral Query into their own
+// parameterised query language.
+type Movies interface {
+	List(ctx context.Context, q Query) ([]domain.Movie, error)
+	Count(ctx context.Context, q Query) (int, error)
+	// Facet counts the movies of the query by the values of the facet, most frequent first.
+	// The sort, keyset and page of the query are ignored.
+	Facet(ctx context.Context, q Query, f Facet) ([]FacetCount, error)
+}
+
+// Each calls fn with every movie of the repository, in ID order, reading them page by page.
+func Each(ctx context.Context, movies Movies, fn func(domain.Movie) error) error {
+	q := Query{Sort: []Sort{{Field: "ID"}}, Limit: 500}
+
+	for {
+		page, err := movies.List(ctx, q)
+		if err != nil {
+			return errors.Wrap(err, "movie list")
+		}
+
+		for _, movie := range page {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < q.Limit {
+			return nil
+		}
+
+		key, err := q.KeyOf(page[len(page)-1])
+		if err != nil {
+			return err
+		}
+		q.After = &key
+	}
+}
+
+// Sort orders a listing by a movie field.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Key is the keyset position of a movie within a sorted listing:
+// the values of its sort fields followed by its ID as tie breaker.
+type Key struct {
+	Values []any `json:"v"`
+	ID     int   `json:"i"`
+}
+
+// Query describes a listing of the movies which are not soft deleted and match the filter.
+// When After is set the listing continues right after that key and Offset is ignored.
+// When Fields is set the movies only carry those fields, the ID and the sort fields.
+type Query struct {
+	Filter Expr
+	Fields []string
+	Sort   []Sort
+	After  *Key
+	Offset int
+	Limit  int
+}
+
+// KeyOf returns the keyset position of the movie for the sort of the query.
+func (q Query) KeyOf(movie domain.Movie) (Key, error) {
+	b, err := json.Marshal(movie)
+	if err != nil {
+		return Key{}, errors.Wrap(err, "movie marshal")
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return Key{}, errors.Wrap(err, "movie unmarshal")
+	}
+
+	key := Key{Values: []any{}, ID: movie.ID}
+	for _, s := range q.Sort {
+		if s.Field != "ID" {
+			key.Values = append(key.Values, doc[s.Field])
+		}
+	}
+	return key, nil
+}
+
+// SortString returns the canonical form of the sort, e.g. "-ReleaseDate,Title".
+func (q Query) SortString() string {
+	fields := make([]string, 0, len(q.Sort))
+	for _, s := range q.Sort {
+		if s.Desc {
+			fields = append(fields, "-"+s.Field)
+		} else {
+			fields = append(fields, s.Field)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// fields returns the fields to select, nil for the whole document. The ID and the sort
+// fields are always selected since the keyset positions are made of them.
+func (q Query) fields() []string {
+	if q.Fields == nil {
+		return nil
+	}
+
+	fields := append([]string{}, q.Fields...)
+	selected := map[string]bool{}
+	for _, f := range fields {
+		selected[f] = true
+	}
+
+	for _, s := range q.sorts() {
+		if !selected[s.Field] {
+			fields = append(fields, s.Field)
+		}
+	}
+	return fields
+}
+
+// sorts returns the sort of the query followed by the ID tie breaker,
+// which makes the order total and the keyset positions unique.
+func (q Query) sorts() []Sort {
+	var sorts []Sort
+	for _, s := range q.Sort {
+		if s.Field != "ID" {
+			sorts = append(sorts, s)
+		}
+	}
+	return append(sorts, Sort{Field: "ID"})
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+)
+
+// Memory is the search backend of the in-memory index, see MemoryIndex.
+const Memory = "memory"
+
+// Highlighted terms are surrounded with these markers.
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
+// Searcher ranks the movies matching a full text query over their title, stories,
+// keywords and cast names.
+type Searcher interface {
+	Search(ctx context.Context, q SearchQuery) (SearchResult, error)
+}
+
+// SearchQuery describes a search among the movies which are not soft deleted and match the filter.
+type SearchQuery struct {
+	Text   string
+	Filter Expr
+	Offset int
+	Limit  int
+}
+
+// SearchHit is a matching movie with its relevance and the matching parts of its
+// Title, ShortStory and Story.
+type SearchHit struct {
+	Movie      domain.Movie      `json:"movie"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+// SearchResult holds a page of hits, most relevant first, and the number of matching movies.
+type SearchResult struct {
+	TotalCount int
+	Hits       []SearchHit
+}
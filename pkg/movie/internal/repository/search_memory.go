@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+)
+
+// BM25 parameters: k1 saturates the term frequency, b normalises it by the field length.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// searchFields are the indexed fields with the boost of their matches.
+var searchFields = []struct {
+	name  string
+	boost float64
+}{
+	{"Title", 3},
+	{"Keywords", 2},
+	{"Cast", 2},
+	{"ShortStory", 1.5},
+	{"Story", 1},
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"that": true, "the": true, "to": true, "was": true, "with": true,
+}
+
+// MemoryIndex is an in-memory inverted index ranking the movies with BM25 over the search fields.
+// It is meant for local development and tests, and is kept current as a movie write hook.
+// Every term of the query must match, like the PostgreSQL backend does.
+type MemoryIndex struct {
+	mu       sync.RWMutex
+	movies   map[int]*indexedMovie
+	postings map[string]map[int]struct{}
+	lengths  map[string]int
+}
+
+type indexedMovie struct {
+	movie   domain.Movie
+	doc     map[string]any
+	freqs   map[string]map[string]int
+	lengths map[string]int
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		movies:   map[int]*indexedMovie{},
+		postings: map[string]map[int]struct{}{},
+		lengths:  map[string]int{},
+	}
+}
+
+// Load indexes every movie of the repository.
+func (x *MemoryIndex) Load(ctx context.Context, movies Movies) error {
+	return Each(ctx, movies, func(movie domain.Movie) error {
+		return x.MovieSaved(ctx, movie)
+	})
+}
+
+// MovieSaved reindexes the movie, soft deleted movies are removed from the index.
+func (x *MemoryIndex) MovieSaved(_ context.Context, movie domain.Movie) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(movie.ID)
+	if movie.IsDeleted() {
+		return nil
+	}
+
+	b, err := json.Marshal(movie)
+	if err != nil {
+		return errors.Wrap(err, "movie marshal")
+	}
+
+	m := &indexedMovie{movie: movie, freqs: map[string]map[string]int{}, lengths: map[string]int{}}
+	if err := json.Unmarshal(b, &m.doc); err != nil {
+		return errors.Wrap(err, "movie unmarshal")
+	}
+
+	for _, f := range searchFields {
+		freqs := map[string]int{}
+		for _, text := range fieldTexts(movie, f.name) {
+			for _, term := range tokenize(text) {
+				freqs[term]++
+				m.lengths[f.name]++
+			}
+		}
+
+		m.freqs[f.name] = freqs
+		x.lengths[f.name] += m.lengths[f.name]
+
+		for term := range freqs {
+			if x.postings[term] == nil {
+				x.postings[term] = map[int]struct{}{}
+			}
+			x.postings[term][movie.ID] = struct{}{}
+		}
+	}
+
+	x.movies[movie.ID] = m
+	return nil
+}
+
+// MoviePurged removes the movie from the index.
+func (x *MemoryIndex) MoviePurged(_ context.Context, id int) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(id)
+	return nil
+}
+
+func (x *MemoryIndex) remove(id int) {
+	m, ok := x.movies[id]
+	if !ok {
+		return
+	}
+
+	for field, freqs := range m.freqs {
+		x.lengths[field] -= m.lengths[field]
+		for term := range freqs {
+			delete(x.postings[term], id)
+			if len(x.postings[term]) == 0 {
+				delete(x.postings, term)
+			}
+		}
+	}
+	delete(x.movies, id)
+}
+
+func (x *MemoryIndex) Search(_ context.Context, q SearchQuery) (SearchResult, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	terms := unique(tokenize(q.Text))
+	res := SearchResult{Hits: []SearchHit{}}
+
+	var hits []SearchHit
+	for _, id := range x.candidates(terms) {
+		m := x.movies[id]
+		if !Match(q.Filter, m.doc) {
+			continue
+		}
+		hits = append(hits, SearchHit{Movie: m.movie, Score: x.score(m, terms)})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Movie.ID < hits[j].Movie.ID
+	})
+
+	res.TotalCount = len(hits)
+	if q.Offset >= len(hits) {
+		return res, nil
+	}
+	if end := q.Offset + q.Limit; end < len(hits) {
+		hits = hits[:end]
+	}
+	hits = hits[q.Offset:]
+
+	for i := range hits {
+		hits[i].Highlights = highlightMovie(hits[i].Movie, terms)
+	}
+	res.Hits = hits
+	return res, nil
+}
+
+// candidates returns the movies containing every term.
+func (x *MemoryIndex) candidates(terms []string) []int {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var ids []int
+	for id := range x.postings[terms[0]] {
+		all := true
+		for _, term := range terms[1:] {
+			if _, ok := x.postings[term][id]; !ok {
+				all = false
+				break
+			}
+		}
+		if all {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (x *MemoryIndex) score(m *indexedMovie, terms []string) float64 {
+	n := float64(len(x.movies))
+
+	var score float64
+	for _, term := range terms {
+		df := float64(len(x.postings[term]))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for _, f := range searchFields {
+			tf := float64(m.freqs[f.name][term])
+			if tf == 0 {
+				continue
+			}
+
+			avg := float64(x.lengths[f.name]) / n
+			norm := 1 - bm25B + bm25B*float64(m.lengths[f.name])/avg
+			score += f.boost * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+	return score
+}
+
+func fieldTexts(m domain.Movie, field string) []string {
+	switch field {
+	case "Title":
+		return []string{m.Title}
+	case "ShortStory":
+		return []string{m.ShortStory}
+	case "Story":
+		return []string{m.Story}
+	case "Keywords":
+		return m.Keywords
+	case "Cast":
+		names := make([]string, 0, len(m.Cast))
+		for _, c := range m.Cast {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+	return nil
+}
+
+// tokenize splits the text into lower cased words, leaving out the stop words.
+func tokenize(text string) []string {
+	var terms []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), isSeparator) {
+		if !stopWords[w] {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func unique(terms []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// highlightMovie returns the matching Title and ShortStory, and a fragment of the matching Story.
+func highlightMovie(m domain.Movie, terms []string) map[string]string {
+	h := map[string]string{}
+	for field, text := range map[string]string{"Title": m.Title, "ShortStory": m.ShortStory} {
+		if s := highlight(text, terms, 0); s != "" {
+			h[field] = s
+		}
+	}
+	if s := highlight(m.Story, terms, 30); s != "" {
+		h["Story"] = s
+	}
+	return h
+}
+
+// highlight surrounds the terms in the text with the markers and escapes the text as HTML. A positive
+// window cuts the text to that many words around the first match. It returns "" when nothing matches.
+func highlight(text string, terms []string, window int) string {
+	type word struct {
+		start, end int
+		hit        bool
+	}
+
+	var words []word
+	start := -1
+	for i, r := range text + " " {
+		switch {
+		case !isSeparator(r) && start < 0:
+			start = i
+		case isSeparator(r) && start >= 0:
+			w := word{start: start, end: i}
+			for _, t := range terms {
+				if strings.ToLower(text[start:i]) == t {
+					w.hit = true
+				}
+			}
+			words = append(words, w)
+			start = -1
+		}
+	}
+
+	first := -1
+	for i, w := range words {
+		if w.hit {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	from, to := 0, len(words)
+	if window > 0 {
+		if from = first - window/3; from < 0 {
+			from = 0
+		}
+		if from+window < to {
+			to = from + window
+		}
+	}
+
+	var sb strings.Builder
+	pos, end := 0, len(text)
+	if from > 0 {
+		sb.WriteString("...")
+		pos = words[from].start
+	}
+	if to < len(words) {
+		end = words[to-1].end
+	}
+
+	for _, w := range words[from:to] {
+		if !w.hit {
+			continue
+		}
+		sb.WriteString(html.EscapeString(text[pos:w.start]))
+		sb.WriteString(highlightStart + html.EscapeString(text[w.start:w.end]) + highlightStop)
+		pos = w.end
+	}
+	sb.WriteString(html.EscapeString(text[pos:end]))
+
+	if to < len(words) {
+		sb.WriteString("...")
+	}
+	return sb.String()
+}
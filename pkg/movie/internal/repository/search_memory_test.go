@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIndex(t *testing.T) *MemoryIndex {
+	x := NewMemoryIndex()
+	for _, m := range []domain.Movie{
+		{ID: 1, Title: "Heat", Genres: []string{"Crime"}, Story: "A group of professional bank robbers start to feel the heat."},
+		{ID: 2, Title: "The Bank Job", Genres: []string{"Crime", "Thriller"}, Keywords: []string{"heist"}},
+		{ID: 3, Title: "Robbers", Genres: []string{"Comedy"}, Cast: []domain.Cast{{Name: "Al Pacino", PersonID: 7}}},
+	} {
+		assert.NoError(t, x.MovieSaved(context.Background(), m))
+	}
+	return x
+}
+
+func TestMemoryIndex_Search(t *testing.T) {
+	testCases := map[string]struct {
+		Query    SearchQuery
+		Expected []int
+	}{
+		"should rank title matches first": {
+			Query:    SearchQuery{Text: "heat", Limit: 10},
+			Expected: []int{1},
+		},
+		"should require every term": {
+			Query:    SearchQuery{Text: "bank robbers", Limit: 10},
+			Expected: []int{1},
+		},
+		"should rank by relevance": {
+			Query:    SearchQuery{Text: "robbers", Limit: 10},
+			Expected: []int{3, 1},
+		},
+		"should search keywords and cast names": {
+			Query:    SearchQuery{Text: "pacino", Limit: 10},
+			Expected: []int{3},
+		},
+		"should apply filter": {
+			Query: SearchQuery{
+				Text:   "robbers",
+				Filter: And{Condition{Field: "Genres", Op: Contains, Value: "Crime"}},
+				Limit:  10,
+			},
+			Expected: []int{1},
+		},
+		"should ignore stop words": {
+			Query:    SearchQuery{Text: "the", Limit: 10},
+			Expected: []int{},
+		},
+	}
+
+	x := newTestIndex(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res, err := x.Search(context.Background(), tc.Query)
+
+			assert.NoError(t, err)
+			ids := []int{}
+			for _, h := range res.Hits {
+				ids = append(ids, h.Movie.ID)
+			}
+			assert.Equal(t, tc.Expected, ids)
+			assert.Equal(t, len(tc.Expected), res.TotalCount)
+		})
+	}
+}
+
+func TestMemoryIndex_Search_ShouldHighlight(t *testing.T) {
+	x := newTestIndex(t)
+
+	res, err := x.Search(context.Background(), SearchQuery{Text: "heat", Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Title": "<mark>Heat</mark>",
+		"Story": "A group of professional bank robbers start to feel the <mark>heat</mark>.",
+	}, res.Hits[0].Highlights)
+}
+
+func TestMemoryIndex_MovieSaved_ShouldRemoveDeletedMovie(t *testing.T) {
+	x := newTestIndex(t)
+	now := time.Now()
+
+	assert.NoError(t, x.MovieSaved(context.Background(), domain.Movie{ID: 1, Title: "Heat", DeletedAt: &now}))
+	assert.NoError(t, x.MoviePurged(context.Background(), 2))
+
+	res, err := x.Search(context.Background(), SearchQuery{Text: "heat bank", Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Empty(t, res.Hits)
+	assert.Empty(t, x.postings["heist"])
+}
+
+func TestHighlight_ShouldCutWindow(t *testing.T) {
+	actual := highlight("one two three four five six seven", []string{"five"}, 3)
+
+	assert.Equal(t, "...four <mark>five</mark> six...", actual)
+}
+
+func TestHighlight_ShouldEscapeHTML(t *testing.T) {
+	actual := highlight(`<script>alert("heist")</script> & heist`, []string{"heist"}, 0)
+
+	assert.Equal(t, `&lt;script&gt;alert(&#34;<mark>heist</mark>&#34;)&lt;/script&gt; &amp; <mark>heist</mark>`, actual)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// highlightFields are the fields returned highlighted with their ts_headline options.
+var highlightFields = []struct{ name, options string }{
+	{"Title", "HighlightAll=true"},
+	{"ShortStory", "HighlightAll=true"},
+	{"Story", "MaxFragments=2"},
+}
+
+// Search matches the query, parsed with websearch_to_tsquery, against the generated search
+// column of the movie table and ranks the movies with ts_rank_cd.
+func (r *PostgreSQLMovies) Search(ctx context.Context, q SearchQuery) (SearchResult, error) {
+	b := newBuilder(postgresql{}, Query{Filter: q.Filter})
+	text := b.bindRaw(q.Text)
+	b.where = append(b.where, "search @@ query")
+
+	columns := []string{"doc", "ts_rank_cd(search, query) AS score", "count(*) OVER ()"}
+	for _, f := range highlightFields {
+		columns = append(columns, "ts_headline('english', "+escapeHTML("coalesce(doc ->> "+pqString(f.name)+", '')")+", query, "+
+			pqString("StartSel="+highlightStart+", StopSel="+highlightStop+", "+f.options)+")")
+	}
+
+	query := "SELECT " + strings.Join(columns, ", ") +
+		" FROM movie, websearch_to_tsquery('english', " + text + ") query" + b.whereClause() +
+		" ORDER BY score DESC, (doc -> 'ID') ASC LIMIT " + b.bindRaw(q.Limit) + " OFFSET " + b.bindRaw(q.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, b.params...)
+	if err != nil {
+		return SearchResult{}, errors.Wrap(err, "postgresql query")
+	}
+	defer rows.Close()
+
+	res := SearchResult{Hits: []SearchHit{}}
+
+	for rows.Next() {
+		var (
+			doc       []byte
+			hit       SearchHit
+			headlines = make([]string, len(highlightFields))
+			dest      = []any{&doc, &hit.Score, &res.TotalCount}
+		)
+		for i := range headlines {
+			dest = append(dest, &headlines[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return SearchResult{}, errors.Wrap(err, "row scan")
+		}
+
+		if err := json.Unmarshal(doc, &hit.Movie); err != nil {
+			return SearchResult{}, errors.Wrap(err, "row parse")
+		}
+
+		hit.Highlights = highlights(headlines)
+		res.Hits = append(res.Hits, hit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return SearchResult{}, errors.Wrap(err, "rows")
+	}
+
+	return res, nil
+}
+
+// htmlEscapes are the replacements of html.EscapeString, ampersands first.
+var htmlEscapes = [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"'", "&#39;"}}
+
+// escapeHTML wraps the SQL expression so that it is escaped like html.EscapeString, the highlighted
+// text is returned as HTML.
+func escapeHTML(expr string) string {
+	for _, e := range htmlEscapes {
+		expr = "replace(" + expr + ", " + pqString(e[0]) + ", " + pqString(e[1]) + ")"
+	}
+	return expr
+}
+
+// highlights keeps the headlines which contain a match.
+func highlights(headlines []string) map[string]string {
+	h := map[string]string{}
+	for i, headline := range headlines {
+		if strings.Contains(headline, highlightStart) {
+			h[highlightFields[i].name] = headline
+		}
+	}
+	return h
+}
@@ -11,22 +11,32 @@ import (
 	"github.com/3n0ugh/allotropes/framework/application"
 	"github.com/3n0ugh/allotropes/internal/errors"
 	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/internal/sequence"
 	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
 	"github.com/couchbase/gocb/v2"
 )
 
 type AddMovie struct {
-	Repo *gocb.Bucket
+	Repo      *gocb.Bucket
+	IDs       sequence.Sequence
+	People    People
+	Terms     Terms
+	Hooks     Hooks
+	Revisions *Revisions
 }
 
 type AddMovieRequest struct {
-	Movie domain.Movie `json:"movie"`
+	Reason string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author string
+	Movie  domain.Movie `json:"movie"`
 }
 
-type AddMovieResponse struct{}
+type AddMovieResponse struct {
+	ID int `json:"id"`
+}
 
-func NewAddMovie(repo *gocb.Bucket) *AddMovie {
-	return &AddMovie{Repo: repo}
+func NewAddMovie(repo *gocb.Bucket, ids sequence.Sequence, people People, terms Terms, hooks Hooks, revisions *Revisions) *AddMovie {
+	return &AddMovie{Repo: repo, IDs: ids, People: people, Terms: terms, Hooks: hooks, Revisions: revisions}
 }
 
 func (m *AddMovie) Route(ctx context.Context) application.Route {
@@ -35,7 +45,7 @@ func (m *AddMovie) Route(ctx context.Context) application.Route {
 		Description: "Add movie",
 		Method:      http.MethodPost,
 		Path:        "/v1/movies",
-		Headers:     map[string]string{},
+		Headers:     map[string]string{"Location": "path of the created movie"},
 		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
 		Handler:     m.endpoint(ctx),
 		Request:     AddMovieRequest{},
@@ -57,22 +67,56 @@ func (m *AddMovie) endpoint(ctx context.Context) application.HandlerFunc {
 			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "movie body unmarshal").Error())
 		}
 
-		return m.handle(ctx, AddMovieRequest{Movie: movie})
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, AddMovieRequest{Reason: r.Header.Get("X-Change-Reason"), Author: principal.Email, Movie: movie})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", "/v1/movies/"+strconv.Itoa(res.ID))
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
 	}
 }
 
 func (m *AddMovie) handle(ctx context.Context, r AddMovieRequest) (*AddMovieResponse, error) {
+	if r.Movie.ID != 0 {
+		return nil, errors.NewBadRequestError("movie id is assigned by the server", "client supplied movie id")
+	}
+
+	r.Movie.DeletedAt, r.Movie.DeletedBy = nil, ""
+	r.Movie.Images = domain.Images{}
+	r.Movie.SyncReleaseDate()
+
 	err := r.Movie.Validate()
 	if err != nil {
 		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
 	}
 
-	err = m.repo(ctx, r.Movie)
+	err = checkPeople(ctx, m.People, creditedPeople(r.Movie)...)
 	if err != nil {
-		return nil, errors.NewInternalServerError(errors.Wrap(err, "couchbase query").Error())
+		return nil, err
 	}
 
-	return &AddMovieResponse{}, nil
+	err = normalizeTerms(ctx, m.Terms, &r.Movie, domain.Movie{})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Movie.ID, err = sequence.Insert(ctx, m.IDs, func(id int) error {
+		r.Movie.ID = id
+		return m.repo(ctx, r.Movie)
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	m.Hooks.saved(ctx, r.Movie)
+
+	m.Revisions.record(r.Movie, domain.ActionCreate, r.Author, r.Reason)
+
+	return &AddMovieResponse{ID: r.Movie.ID}, nil
 }
 
 func (m *AddMovie) repo(_ context.Context, movie domain.Movie) error {
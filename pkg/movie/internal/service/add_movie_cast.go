@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type AddMovieCast struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	People         People
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+// CastCredit is a cast credit to write. Without CastOrder the credit is billed last,
+// otherwise the credits from that position on move down by one.
+type CastCredit struct {
+	CastOrder *int   `json:"CastOrder"`
+	Gender    string `json:"Gender"`
+	Name      string `json:"Name"`
+	PersonID  int    `json:"PersonID"`
+}
+
+func (c CastCredit) Cast() domain.Cast {
+	return domain.Cast{Gender: c.Gender, Name: c.Name, PersonID: c.PersonID}
+}
+
+type AddMovieCastRequest struct {
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason  string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author  string
+	Credit  CastCredit `json:"credit"`
+}
+
+type AddMovieCastResponse struct {
+	Cast domain.Cast `json:"cast"`
+
+	etag string
+}
+
+func NewAddMovieCast(repo *gocb.Bucket, requireIfMatch bool, people People, hooks Hooks, revisions *Revisions) *AddMovieCast {
+	return &AddMovieCast{Repo: repo, RequireIfMatch: requireIfMatch, People: people, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *AddMovieCast) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Add Movie Cast",
+		Description: "Add cast credit to the movie",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies/{id}/cast",
+		Headers:     map[string]string{"Location": "path of the created credit", "ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     AddMovieCastRequest{},
+		Response:    AddMovieCastResponse{},
+	}
+}
+
+func (m *AddMovieCast) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var credit CastCredit
+
+		err = json.Unmarshal(body, &credit)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "cast body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, AddMovieCastRequest{
+			ID:      id,
+			IfMatch: r.Header.Get("If-Match"),
+			Reason:  r.Header.Get("X-Change-Reason"),
+			Author:  principal.Email,
+			Credit:  credit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", "/v1/movies/"+idStr+"/cast/"+strconv.Itoa(res.Cast.PersonID))
+		w.Header().Set("ETag", res.etag)
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+func (m *AddMovieCast) handle(ctx context.Context, r AddMovieCastRequest) (*AddMovieCastResponse, error) {
+	credit := r.Credit.Cast()
+
+	err := credit.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	if r.Credit.CastOrder != nil && *r.Credit.CastOrder < 0 {
+		return nil, errors.NewBadRequestError("cast order must not be negative", "negative cast order")
+	}
+
+	err = checkPeople(ctx, m.People, credit.PersonID)
+	if err != nil {
+		return nil, err
+	}
+
+	cas, err := mutateCredits(m.Repo, r.ID, "Cast", r.IfMatch, m.RequireIfMatch, func(cast []domain.Cast) ([]domain.Cast, error) {
+		if castIndex(cast, credit.PersonID) >= 0 {
+			return nil, errors.NewConflictError("person is already in the cast", "duplicate cast person "+strconv.Itoa(credit.PersonID))
+		}
+
+		cast = placeCast(orderCast(cast), credit, r.Credit.CastOrder)
+		credit = cast[castIndex(cast, credit.PersonID)]
+		return cast, nil
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	creditsSaved(ctx, m.Repo, m.Hooks, m.Revisions, r.ID, r.Author, r.Reason)
+
+	return &AddMovieCastResponse{Cast: credit, etag: etag.Format(cas)}, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type AddMovieCrew struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	People         People
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type AddMovieCrewRequest struct {
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason  string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author  string
+	Credit  domain.Crew `json:"credit"`
+}
+
+type AddMovieCrewResponse struct {
+	Crew domain.Crew `json:"crew"`
+
+	etag string
+}
+
+func NewAddMovieCrew(repo *gocb.Bucket, requireIfMatch bool, people People, hooks Hooks, revisions *Revisions) *AddMovieCrew {
+	return &AddMovieCrew{Repo: repo, RequireIfMatch: requireIfMatch, People: people, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *AddMovieCrew) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Add Movie Crew",
+		Description: "Add crew credit to the movie",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies/{id}/crew",
+		Headers:     map[string]string{"Location": "path of the created credit", "ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     AddMovieCrewRequest{},
+		Response:    AddMovieCrewResponse{},
+	}
+}
+
+func (m *AddMovieCrew) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var credit domain.Crew
+
+		err = json.Unmarshal(body, &credit)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "crew body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, AddMovieCrewRequest{
+			ID:      id,
+			IfMatch: r.Header.Get("If-Match"),
+			Reason:  r.Header.Get("X-Change-Reason"),
+			Author:  principal.Email,
+			Credit:  credit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", "/v1/movies/"+idStr+"/crew/"+strconv.Itoa(res.Crew.PersonID)+"?job="+url.QueryEscape(res.Crew.Job))
+		w.Header().Set("ETag", res.etag)
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+func (m *AddMovieCrew) handle(ctx context.Context, r AddMovieCrewRequest) (*AddMovieCrewResponse, error) {
+	err := r.Credit.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = checkPeople(ctx, m.People, r.Credit.PersonID)
+	if err != nil {
+		return nil, err
+	}
+
+	cas, err := mutateCredits(m.Repo, r.ID, "Crew", r.IfMatch, m.RequireIfMatch, func(crew []domain.Crew) ([]domain.Crew, error) {
+		if crewIndex(crew, r.Credit.PersonID, r.Credit.Job) >= 0 {
+			return nil, errors.NewConflictError("person already holds the job in the crew", "duplicate crew person "+strconv.Itoa(r.Credit.PersonID)+" "+r.Credit.Job)
+		}
+		return append(crew[:len(crew):len(crew)], r.Credit), nil
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	creditsSaved(ctx, m.Repo, m.Hooks, m.Revisions, r.ID, r.Author, r.Reason)
+
+	return &AddMovieCrewResponse{Crew: r.Credit, etag: etag.Format(cas)}, nil
+}
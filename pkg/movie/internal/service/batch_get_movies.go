@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/locale"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+const (
+	maxBatchIDs = 500
+	// batchConcurrency bounds the key value gets in flight for a batch.
+	batchConcurrency = 16
+)
+
+type BatchGetMovies struct {
+	Repo *gocb.Bucket
+}
+
+type BatchGetMoviesRequest struct {
+	IDs            []int  `json:"ids" description:"movie ids, at most 500"`
+	AcceptLanguage string `header:"Accept-Language" description:"preferred locales of the titles and stories, the original languages when none is translated"`
+}
+
+type BatchGetMoviesResponse struct {
+	Movies  []domain.Movie `json:"movies"`
+	Missing []int          `json:"missing"`
+
+	language string
+}
+
+func NewBatchGetMovies(repo *gocb.Bucket) *BatchGetMovies {
+	return &BatchGetMovies{Repo: repo}
+}
+
+func (m *BatchGetMovies) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Batch Get Movies",
+		Description: "Get movies by ids in request order, ids of missing movies are reported apart",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies:batchGet",
+		Headers:     map[string]string{"Content-Language": "locales of the titles and stories"},
+		Handler:     m.endpoint(ctx),
+		Request:     BatchGetMoviesRequest{},
+		Response:    BatchGetMoviesResponse{},
+	}
+}
+
+func (m *BatchGetMovies) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var req BatchGetMoviesRequest
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "batch body unmarshal").Error())
+		}
+
+		req.AcceptLanguage = r.Header.Get("Accept-Language")
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		writeLanguage(w, res.language)
+		return res, nil
+	}
+}
+
+// parseIDs parses the comma separated ids of GET /v1/movies?ids=.
+func parseIDs(s string) ([]int, error) {
+	var ids []int
+	for _, v := range strings.Split(s, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, errors.NewBadRequestError("ids must be comma separated integers", errors.Wrap(err, "ids conversion").Error())
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (m *BatchGetMovies) handle(_ context.Context, r BatchGetMoviesRequest) (*BatchGetMoviesResponse, error) {
+	if len(r.IDs) == 0 || len(r.IDs) > maxBatchIDs {
+		return nil, errors.NewBadRequestError("ids must hold between 1 and 500 ids", "batch size "+strconv.Itoa(len(r.IDs)))
+	}
+
+	ids := make([]int, 0, len(r.IDs))
+	seen := map[int]bool{}
+	for _, id := range r.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	movies, err := m.repo(ids)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	res := &BatchGetMoviesResponse{Movies: []domain.Movie{}, Missing: []int{}}
+	for i, movie := range movies {
+		if movie == nil {
+			res.Missing = append(res.Missing, ids[i])
+			continue
+		}
+		res.Movies = append(res.Movies, *movie)
+	}
+
+	res.language = localize(res.Movies, locale.ParseAcceptLanguage(r.AcceptLanguage))
+	return res, nil
+}
+
+// repo gets the movies concurrently, at most batchConcurrency at a time. The result is in the
+// order of the ids, holding nil for missing and soft deleted movies. Any other failure fails the batch.
+func (m *BatchGetMovies) repo(ids []int) ([]*domain.Movie, error) {
+	var (
+		movies = make([]*domain.Movie, len(ids))
+		errs   = make([]error, len(ids))
+		sem    = make(chan struct{}, batchConcurrency)
+		wg     sync.WaitGroup
+	)
+
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i, id int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			movie, _, err := getMovie(m.Repo, id)
+			if err != nil && !errors.Is(err, gocb.ErrDocumentNotFound) && !errors.Is(err, errors.ErrNotFound) {
+				errs[i] = errors.Wrap(err, "movie "+strconv.Itoa(id))
+				return
+			}
+			movies[i] = movie
+		}(i, id)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return movies, nil
+}
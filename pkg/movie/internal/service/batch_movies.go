@@ -0,0 +1,389 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/internal/sequence"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+const maxBatchOperations = 100
+
+// Batch operations.
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// BatchMovies applies a list of writes. Operations go through the single movie services, or
+// through a Couchbase transaction in atomic mode.
+type BatchMovies struct {
+	Cluster *gocb.Cluster
+	Add     *AddMovie
+	Update  *UpdateMovie
+	Delete  *DeleteMovie
+}
+
+type BatchMoviesRequest struct {
+	Author     string
+	Atomic     bool                  `json:"atomic" description:"apply every operation or none of them"`
+	Operations []BatchMovieOperation `json:"operations"`
+}
+
+type BatchMovieOperation struct {
+	Op      string        `json:"op" description:"create, update or delete"`
+	ID      int           `json:"id,omitempty" description:"id of the updated or deleted movie"`
+	IfMatch string        `json:"ifMatch,omitempty" description:"entity tag the movie must still have"`
+	Reason  string        `json:"reason,omitempty" description:"reason recorded in the movie revision"`
+	Movie   *domain.Movie `json:"movie,omitempty"`
+}
+
+type BatchMoviesResponse struct {
+	Results []BatchMovieResult `json:"results"`
+}
+
+// BatchMovieResult is the outcome of an operation, results are in the order of the operations.
+type BatchMovieResult struct {
+	Status int           `json:"status"`
+	ID     int           `json:"id,omitempty"`
+	ETag   string        `json:"etag,omitempty"`
+	Error  *errors.Error `json:"error,omitempty"`
+}
+
+func NewBatchMovies(cluster *gocb.Cluster, add *AddMovie, update *UpdateMovie, del *DeleteMovie) *BatchMovies {
+	return &BatchMovies{Cluster: cluster, Add: add, Update: update, Delete: del}
+}
+
+func (m *BatchMovies) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Batch Movies",
+		Description: "Create, update and delete movies in one request, answered with the status of each operation",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies:batch",
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     BatchMoviesRequest{},
+		Response:    BatchMoviesResponse{},
+	}
+}
+
+func (m *BatchMovies) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var req BatchMoviesRequest
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "batch body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+		req.Author = principal.Email
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		w.WriteHeader(http.StatusMultiStatus)
+		return res, nil
+	}
+}
+
+func (m *BatchMovies) handle(ctx context.Context, r BatchMoviesRequest) (*BatchMoviesResponse, error) {
+	if len(r.Operations) == 0 || len(r.Operations) > maxBatchOperations {
+		return nil, errors.NewBadRequestError("operations must hold between 1 and 100 operations", "batch size "+strconv.Itoa(len(r.Operations)))
+	}
+
+	if r.Atomic {
+		return m.atomic(ctx, r)
+	}
+
+	var (
+		results = make([]BatchMovieResult, len(r.Operations))
+		sem     = make(chan struct{}, batchConcurrency)
+		wg      sync.WaitGroup
+	)
+
+	for i, op := range r.Operations {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, op BatchMovieOperation) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = m.apply(ctx, r.Author, op)
+		}(i, op)
+	}
+	wg.Wait()
+
+	return &BatchMoviesResponse{Results: results}, nil
+}
+
+// apply runs the operation with the single movie service.
+func (m *BatchMovies) apply(ctx context.Context, author string, op BatchMovieOperation) BatchMovieResult {
+	if op.Op != OpDelete && op.Movie == nil {
+		return failed(op.ID, errors.NewBadRequestError("movie is required", "batch operation without movie"))
+	}
+
+	switch op.Op {
+	case OpCreate:
+		res, err := m.Add.handle(ctx, AddMovieRequest{Reason: op.Reason, Author: author, Movie: *op.Movie})
+		if err != nil {
+			return failed(0, err)
+		}
+		return BatchMovieResult{Status: http.StatusCreated, ID: res.ID}
+	case OpUpdate:
+		res, err := m.Update.handle(ctx, UpdateMovieRequest{ID: op.ID, IfMatch: op.IfMatch, Reason: op.Reason, Author: author, Movie: *op.Movie})
+		if err != nil {
+			return failed(op.ID, err)
+		}
+		return BatchMovieResult{Status: http.StatusOK, ID: op.ID, ETag: res.etag}
+	case OpDelete:
+		_, err := m.Delete.handle(ctx, DeleteMovieRequest{ID: op.ID, IfMatch: op.IfMatch, Reason: op.Reason, DeletedBy: author})
+		if err != nil {
+			return failed(op.ID, err)
+		}
+		return BatchMovieResult{Status: http.StatusNoContent, ID: op.ID}
+	}
+	return failed(op.ID, errors.NewBadRequestError("op must be create, update or delete", "batch op "+op.Op))
+}
+
+func failed(id int, err error) BatchMovieResult {
+	e := errors.Translate(err)
+	return BatchMovieResult{Status: e.StatusCode, ID: id, Error: e}
+}
+
+// prepared is an operation checked before the transaction: the movie to write and, for
+// updates and deletes, the stored document the transaction must still find.
+type prepared struct {
+	movie  domain.Movie
+	stored []byte
+}
+
+// atomic checks every operation, then writes them all in a Couchbase transaction. The entity tags
+// are checked against a read made before the transaction, which fails when the document changed since.
+// Hooks, revisions and the entity tags of the updated movies follow the commit.
+func (m *BatchMovies) atomic(ctx context.Context, r BatchMoviesRequest) (*BatchMoviesResponse, error) {
+	items := make([]prepared, len(r.Operations))
+	for i, op := range r.Operations {
+		p, err := m.prepare(ctx, r.Author, op)
+		if err != nil {
+			return aborted(r.Operations, i, err), nil
+		}
+		items[i] = p
+	}
+
+	coll := m.Add.Repo.Scope("movie").Collection("movie")
+	failedAt, failure := -1, error(nil)
+
+	_, err := m.Cluster.Transactions().Run(func(tc *gocb.TransactionAttemptContext) error {
+		failedAt, failure = -1, nil
+		for i, op := range r.Operations {
+			if err := write(tc, coll, op, items[i]); err != nil {
+				failedAt, failure = i, err
+				return err
+			}
+		}
+		return nil
+	}, &gocb.TransactionOptions{Timeout: 15 * time.Second})
+	if err != nil {
+		if failure == nil {
+			failure = errors.Wrap(err, "couchbase transaction")
+		}
+		return aborted(r.Operations, failedAt, failure), nil
+	}
+
+	results := make([]BatchMovieResult, len(r.Operations))
+	for i, op := range r.Operations {
+		movie := items[i].movie
+		m.Add.Hooks.saved(ctx, movie)
+
+		action, status := domain.ActionUpdate, http.StatusOK
+		switch op.Op {
+		case OpCreate:
+			action, status = domain.ActionCreate, http.StatusCreated
+		case OpDelete:
+			action, status = domain.ActionDelete, http.StatusNoContent
+		}
+
+		m.Add.Revisions.record(movie, action, r.Author, op.Reason)
+		results[i] = BatchMovieResult{Status: status, ID: movie.ID}
+
+		if op.Op == OpUpdate {
+			results[i].ETag = m.etag(movie.ID)
+		}
+	}
+
+	return &BatchMoviesResponse{Results: results}, nil
+}
+
+// etag reads back the entity tag of a movie the transaction wrote, the cas values staged in the
+// transaction change on commit.
+func (m *BatchMovies) etag(id int) string {
+	_, cas, err := getMovie(m.Add.Repo, id)
+	if err != nil {
+		log.Printf("movie %d batch entity tag: %s", id, err)
+		return ""
+	}
+	return etag.Format(cas)
+}
+
+func (m *BatchMovies) prepare(ctx context.Context, author string, op BatchMovieOperation) (prepared, error) {
+	var p prepared
+
+	switch op.Op {
+	case OpCreate, OpUpdate:
+		if op.Movie == nil {
+			return p, errors.NewBadRequestError("movie is required", "batch operation without movie")
+		}
+		p.movie = *op.Movie
+		p.movie.DeletedAt, p.movie.DeletedBy = nil, ""
+		p.movie.Images = domain.Images{}
+		p.movie.SyncReleaseDate()
+
+		if err := p.movie.Validate(); err != nil {
+			return p, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+		}
+
+	case OpDelete:
+	default:
+		return p, errors.NewBadRequestError("op must be create, update or delete", "batch op "+op.Op)
+	}
+
+	if op.Op == OpCreate {
+		if p.movie.ID != 0 {
+			return p, errors.NewBadRequestError("movie id is assigned by the server", "client supplied movie id")
+		}
+
+		if err := checkPeople(ctx, m.Add.People, creditedPeople(p.movie)...); err != nil {
+			return p, err
+		}
+
+		if err := normalizeTerms(ctx, m.Add.Terms, &p.movie, domain.Movie{}); err != nil {
+			return p, err
+		}
+
+		// The transaction fails on a taken key, so the IDs of stored movies are skipped here.
+		id, err := sequence.Insert(ctx, m.Add.IDs, func(id int) error {
+			res, err := m.Add.Repo.Scope("movie").Collection("movie").Exists(strconv.Itoa(id), &gocb.ExistsOptions{Timeout: 3 * time.Second})
+			if err != nil {
+				return err
+			}
+			if res.Exists() {
+				return gocb.ErrDocumentExists
+			}
+			return nil
+		})
+		if err != nil {
+			return p, errors.Translate(errors.Wrap(err, "movie id"))
+		}
+		p.movie.ID = id
+		return p, nil
+	}
+
+	if p.movie.ID != 0 && p.movie.ID != op.ID {
+		return p, errors.NewBadRequestError("movie id does not match the operation id", "movie id and operation id mismatch")
+	}
+
+	current, cas, err := getMovie(m.Add.Repo, op.ID)
+	if err != nil {
+		return p, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	if err := checkIfMatch(op.IfMatch, m.Update.RequireIfMatch, cas); err != nil {
+		return p, err
+	}
+
+	if p.stored, err = json.Marshal(current); err != nil {
+		return p, errors.NewInternalServerError(errors.Wrap(err, "movie marshal").Error())
+	}
+
+	if op.Op == OpUpdate {
+		if err := checkPeople(ctx, m.Add.People, addedPeople(p.movie, *current)...); err != nil {
+			return p, err
+		}
+
+		if err := normalizeTerms(ctx, m.Add.Terms, &p.movie, *current); err != nil {
+			return p, err
+		}
+	}
+
+	if op.Op == OpDelete {
+		deletedAt := time.Now().UTC()
+		p.movie = *current
+		p.movie.DeletedAt, p.movie.DeletedBy = &deletedAt, author
+	}
+	p.movie.Images = current.Images
+	if p.movie.Translations == nil {
+		p.movie.Translations = current.Translations
+	}
+	p.movie.ID = op.ID
+	return p, nil
+}
+
+func write(tc *gocb.TransactionAttemptContext, coll *gocb.Collection, op BatchMovieOperation, p prepared) error {
+	id := strconv.Itoa(p.movie.ID)
+
+	if op.Op == OpCreate {
+		_, err := tc.Insert(coll, id, p.movie)
+		return err
+	}
+
+	doc, err := tc.Get(coll, id)
+	if err != nil {
+		return err
+	}
+
+	var current domain.Movie
+	if err := doc.Content(&current); err != nil {
+		return errors.Wrap(err, "row parse")
+	}
+
+	stored, err := json.Marshal(current)
+	if err != nil {
+		return errors.Wrap(err, "movie marshal")
+	}
+	if !bytes.Equal(stored, p.stored) {
+		return errors.NewPreconditionFailedError("movie has been modified", "movie changed during the batch")
+	}
+
+	_, err = tc.Replace(doc, p.movie)
+	return err
+}
+
+// aborted reports a batch of which nothing was applied. The failing operation carries the
+// error, the others 424 Failed Dependency. Without a failing operation every one carries the error.
+func aborted(ops []BatchMovieOperation, failedAt int, err error) *BatchMoviesResponse {
+	results := make([]BatchMovieResult, len(ops))
+	for i, op := range ops {
+		switch {
+		case failedAt < 0 || i == failedAt:
+			results[i] = failed(op.ID, err)
+		default:
+			results[i] = failed(op.ID, errors.NewFailedDependencyError(
+				"not applied, operation "+strconv.Itoa(failedAt)+" failed", "batch aborted"))
+		}
+	}
+	return &BatchMoviesResponse{Results: results}
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+)
+
+// collectionKeeper takes purged movies out of their collection, so that the positions of the
+// movies left stay contiguous. Movies in the trash keep their place to come back to on restore.
+type collectionKeeper struct {
+	collections catalog.Collections
+}
+
+// NewCollectionKeeper returns the hook which takes purged movies out of their collection.
+func NewCollectionKeeper(collections catalog.Collections) Hook {
+	return &collectionKeeper{collections: collections}
+}
+
+func (k *collectionKeeper) MovieSaved(context.Context, domain.Movie) error { return nil }
+
+func (k *collectionKeeper) MoviePurged(ctx context.Context, id int) error {
+	return k.collections.RemoveMovie(ctx, id)
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/jsonpatch"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+// maxCreditRetries bounds how often a credit write is retried after losing a race against
+// a write to another credit of the same movie.
+const maxCreditRetries = 5
+
+// getCredits returns the credits stored at the field of the movie with the cas value of the movie.
+// Soft deleted movies are reported as not found.
+func getCredits[T any](repo *gocb.Bucket, id int, field string) ([]T, uint64, error) {
+	res, err := repo.Scope("movie").Collection("movie").LookupIn(strconv.Itoa(id), []gocb.LookupInSpec{
+		gocb.GetSpec(field, nil),
+		gocb.ExistsSpec("DeletedAt", nil),
+	}, &gocb.LookupInOptions{Timeout: 3 * time.Second})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "couchbase query")
+	}
+
+	if res.Exists(1) {
+		return nil, 0, errors.Wrap(errors.ErrNotFound, "movie is deleted")
+	}
+
+	var credits []T
+	if err := res.ContentAt(0, &credits); err != nil {
+		return nil, 0, errors.Wrap(err, "row parse")
+	}
+	return credits, uint64(res.Cas()), nil
+}
+
+// mutateCredits replaces the credits stored at the field with the result of mutate. Only the
+// changed array elements are written, guarded by the cas value they were read with. When another
+// credit of the movie was written in between, the credits are read and mutated again instead of
+// overwriting that write. Requests with If-Match fail on such a race instead.
+func mutateCredits[T any](repo *gocb.Bucket, id int, field, ifMatch string, requireIfMatch bool, mutate func([]T) ([]T, error)) (uint64, error) {
+	for attempt := 0; ; attempt++ {
+		credits, cas, err := getCredits[T](repo, id, field)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := checkIfMatch(ifMatch, requireIfMatch, cas); err != nil {
+			return 0, err
+		}
+
+		updated, err := mutate(credits)
+		if err != nil {
+			return 0, err
+		}
+
+		ops, err := jsonpatch.Diff(map[string]any{field: credits}, map[string]any{field: updated})
+		if err != nil {
+			return 0, errors.Wrap(err, "credits diff")
+		}
+
+		if len(ops) == 0 {
+			return cas, nil
+		}
+
+		specs, err := mutateInSpecs(ops, map[string]any{field: updated})
+		if err != nil {
+			return 0, errors.Wrap(err, "mutate in specs")
+		}
+
+		res, err := repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(id), specs, &gocb.MutateInOptions{
+			Cas:     gocb.Cas(cas),
+			Timeout: 5 * time.Second,
+		})
+		if errors.Is(err, gocb.ErrCasMismatch) && ifMatch == "" && attempt < maxCreditRetries {
+			continue
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, "couchbase query")
+		}
+		return uint64(res.Cas()), nil
+	}
+}
+
+// creditsSaved notifies the hooks and records the revision after a credit write. When the movie
+// cannot be read back, the revision reads it when it is retried.
+func creditsSaved(ctx context.Context, repo *gocb.Bucket, hooks Hooks, revisions *Revisions, id int, author, reason string) {
+	movie, _, err := getMovie(repo, id)
+	if err != nil {
+		log.Printf("movie %d credits saved: %s", id, err)
+		revisions.store(domain.Revision{MovieID: id, Action: domain.ActionUpdate, Author: author, Reason: reason, CreatedAt: time.Now().UTC()})
+		return
+	}
+
+	hooks.saved(ctx, *movie)
+	revisions.record(*movie, domain.ActionUpdate, author, reason)
+}
+
+// orderCast returns a copy of the cast sorted by CastOrder and renumbered from zero, so that
+// the billing stays contiguous.
+func orderCast(cast []domain.Cast) []domain.Cast {
+	ordered := append([]domain.Cast{}, cast...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].CastOrder < ordered[j].CastOrder
+	})
+
+	for i := range ordered {
+		ordered[i].CastOrder = i
+	}
+	return ordered
+}
+
+// placeCast inserts the credit into the ordered cast at the billing position, or at the end
+// when no position is given. Credits billed after it move down by one.
+func placeCast(cast []domain.Cast, credit domain.Cast, order *int) []domain.Cast {
+	i := len(cast)
+	if order != nil && *order < i {
+		i = *order
+	}
+
+	placed := append(append(append([]domain.Cast{}, cast[:i]...), credit), cast[i:]...)
+	for j := range placed {
+		placed[j].CastOrder = j
+	}
+	return placed
+}
+
+func castIndex(cast []domain.Cast, personID int) int {
+	for i, c := range cast {
+		if c.PersonID == personID {
+			return i
+		}
+	}
+	return -1
+}
+
+func crewIndex(crew []domain.Crew, personID int, job string) int {
+	for i, c := range crew {
+		if c.Is(personID, job) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findCrew returns the index of the job of the person in the crew. Without a job the person
+// has to hold a single job.
+func findCrew(crew []domain.Crew, personID int, job string) (int, error) {
+	found := -1
+	for i, c := range crew {
+		if c.PersonID != personID || (job != "" && c.Job != job) {
+			continue
+		}
+		if found >= 0 {
+			return -1, errors.NewBadRequestError("person holds several jobs in the crew, job is required", "ambiguous crew person "+strconv.Itoa(personID))
+		}
+		found = i
+	}
+
+	if found < 0 {
+		return -1, errors.NewNotFoundError("person is not in the crew", "crew person "+strconv.Itoa(personID)+" "+job)
+	}
+	return found, nil
+}
+
+func parsePersonID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.NewBadRequestError("person id must be integer", errors.Wrap(err, "person id conversion").Error())
+	}
+	return id, nil
+}
@@ -9,22 +9,29 @@ import (
 	"github.com/3n0ugh/allotropes/framework/application"
 	"github.com/3n0ugh/allotropes/internal/errors"
 	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
 	"github.com/couchbase/gocb/v2"
 	"github.com/go-chi/chi"
 )
 
 type DeleteMovie struct {
-	Repo *gocb.Bucket
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
 }
 
 type DeleteMovieRequest struct {
-	ID int `path:"id"`
+	ID        int    `path:"id"`
+	IfMatch   string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason    string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	DeletedBy string
 }
 
 type DeleteMovieResponse struct{}
 
-func NewDeleteMovie(repo *gocb.Bucket) *DeleteMovie {
-	return &DeleteMovie{Repo: repo}
+func NewDeleteMovie(repo *gocb.Bucket, requireIfMatch bool, hooks Hooks, revisions *Revisions) *DeleteMovie {
+	return &DeleteMovie{Repo: repo, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
 }
 
 func (m *DeleteMovie) Route(ctx context.Context) application.Route {
@@ -50,21 +57,49 @@ func (m *DeleteMovie) endpoint(ctx context.Context) application.HandlerFunc {
 			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
 		}
 
-		return m.handle(ctx, DeleteMovieRequest{ID: id})
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, DeleteMovieRequest{
+			ID:        id,
+			IfMatch:   r.Header.Get("If-Match"),
+			Reason:    r.Header.Get("X-Change-Reason"),
+			DeletedBy: principal.Email,
+		})
 	}
 }
 
 func (m *DeleteMovie) handle(ctx context.Context, r DeleteMovieRequest) (*DeleteMovieResponse, error) {
-	err := m.repo(ctx, r.ID)
+	movie, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedAt := time.Now().UTC()
+	movie.DeletedAt, movie.DeletedBy = &deletedAt, r.DeletedBy
+
+	err = m.repo(ctx, *movie, cas)
 	if err != nil {
-		return nil, errors.NewInternalServerError(errors.Wrap(err, "couchbase query").Error())
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
 	}
 
+	m.Hooks.saved(ctx, *movie)
+
+	m.Revisions.record(*movie, domain.ActionDelete, r.DeletedBy, r.Reason)
+
 	return &DeleteMovieResponse{}, nil
 }
 
-func (m *DeleteMovie) repo(_ context.Context, id int) error {
-	_, err := m.Repo.Scope("movie").Collection("movie").Remove(strconv.Itoa(id), &gocb.RemoveOptions{Timeout: 5 * time.Second})
+// repo soft deletes the movie by marking the document, the purge trash job removes it later.
+func (m *DeleteMovie) repo(_ context.Context, movie domain.Movie, cas uint64) error {
+	_, err := m.Repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(movie.ID), []gocb.MutateInSpec{
+		gocb.InsertSpec("DeletedAt", movie.DeletedAt, nil),
+		gocb.UpsertSpec("DeletedBy", movie.DeletedBy, nil),
+	}, &gocb.MutateInOptions{Cas: gocb.Cas(cas), Timeout: 5 * time.Second})
 	if err != nil {
 		return errors.Wrap(err, "couchbase query")
 	}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type DeleteMovieCast struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type DeleteMovieCastRequest struct {
+	ID       int    `path:"id"`
+	PersonID int    `path:"personId"`
+	IfMatch  string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason   string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author   string
+}
+
+type DeleteMovieCastResponse struct {
+	etag string
+}
+
+func NewDeleteMovieCast(repo *gocb.Bucket, requireIfMatch bool, hooks Hooks, revisions *Revisions) *DeleteMovieCast {
+	return &DeleteMovieCast{Repo: repo, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *DeleteMovieCast) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Movie Cast",
+		Description: "Remove cast credit of the person, credits billed after it move up by one",
+		Method:      http.MethodDelete,
+		Path:        "/v1/movies/{id}/cast/{personId}",
+		Headers:     map[string]string{"ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteMovieCastRequest{},
+		Response:    DeleteMovieCastResponse{},
+	}
+}
+
+func (m *DeleteMovieCast) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		personID, err := parsePersonID(chi.URLParam(r, "personId"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, DeleteMovieCastRequest{
+			ID:       id,
+			PersonID: personID,
+			IfMatch:  r.Header.Get("If-Match"),
+			Reason:   r.Header.Get("X-Change-Reason"),
+			Author:   principal.Email,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *DeleteMovieCast) handle(ctx context.Context, r DeleteMovieCastRequest) (*DeleteMovieCastResponse, error) {
+	cas, err := mutateCredits(m.Repo, r.ID, "Cast", r.IfMatch, m.RequireIfMatch, func(cast []domain.Cast) ([]domain.Cast, error) {
+		cast = orderCast(cast)
+
+		i := castIndex(cast, r.PersonID)
+		if i < 0 {
+			return nil, errors.NewNotFoundError("person is not in the cast", "cast person "+strconv.Itoa(r.PersonID))
+		}
+
+		return orderCast(append(cast[:i:i], cast[i+1:]...)), nil
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	creditsSaved(ctx, m.Repo, m.Hooks, m.Revisions, r.ID, r.Author, r.Reason)
+
+	return &DeleteMovieCastResponse{etag: etag.Format(cas)}, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type DeleteMovieCrew struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type DeleteMovieCrewRequest struct {
+	ID       int    `path:"id"`
+	PersonID int    `path:"personId"`
+	Job      string `query:"job" description:"job of the credit, required when the person holds several jobs"`
+	IfMatch  string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason   string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author   string
+}
+
+type DeleteMovieCrewResponse struct {
+	etag string
+}
+
+func NewDeleteMovieCrew(repo *gocb.Bucket, requireIfMatch bool, hooks Hooks, revisions *Revisions) *DeleteMovieCrew {
+	return &DeleteMovieCrew{Repo: repo, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *DeleteMovieCrew) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Movie Crew",
+		Description: "Remove crew credit of the person",
+		Method:      http.MethodDelete,
+		Path:        "/v1/movies/{id}/crew/{personId}",
+		Headers:     map[string]string{"ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteMovieCrewRequest{},
+		Response:    DeleteMovieCrewResponse{},
+	}
+}
+
+func (m *DeleteMovieCrew) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		personID, err := parsePersonID(chi.URLParam(r, "personId"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, DeleteMovieCrewRequest{
+			ID:       id,
+			PersonID: personID,
+			Job:      r.URL.Query().Get("job"),
+			IfMatch:  r.Header.Get("If-Match"),
+			Reason:   r.Header.Get("X-Change-Reason"),
+			Author:   principal.Email,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *DeleteMovieCrew) handle(ctx context.Context, r DeleteMovieCrewRequest) (*DeleteMovieCrewResponse, error) {
+	cas, err := mutateCredits(m.Repo, r.ID, "Crew", r.IfMatch, m.RequireIfMatch, func(crew []domain.Crew) ([]domain.Crew, error) {
+		i, err := findCrew(crew, r.PersonID, r.Job)
+		if err != nil {
+			return nil, err
+		}
+		return append(crew[:i:i], crew[i+1:]...), nil
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	creditsSaved(ctx, m.Repo, m.Hooks, m.Revisions, r.ID, r.Author, r.Reason)
+
+	return &DeleteMovieCrewResponse{etag: etag.Format(cas)}, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/blob"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/artwork"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type DeleteMovieImage struct {
+	Repo           *gocb.Bucket
+	Store          blob.Store
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type DeleteMovieImageRequest struct {
+	ID      int    `path:"id"`
+	Kind    string `path:"kind" description:"poster or backdrop"`
+	IfMatch string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason  string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author  string
+}
+
+type DeleteMovieImageResponse struct{}
+
+func NewDeleteMovieImage(repo *gocb.Bucket, store blob.Store, requireIfMatch bool, hooks Hooks, revisions *Revisions) *DeleteMovieImage {
+	return &DeleteMovieImage{Repo: repo, Store: store, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *DeleteMovieImage) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Movie Image",
+		Description: "Delete the poster or backdrop of the movie with its thumbnails",
+		Method:      http.MethodDelete,
+		Path:        "/v1/movies/{id}/images/{kind}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteMovieImageRequest{},
+		Response:    DeleteMovieImageResponse{},
+	}
+}
+
+func (m *DeleteMovieImage) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		kind, err := parseImageKind(chi.URLParam(r, "kind"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, DeleteMovieImageRequest{
+			ID:      id,
+			Kind:    kind,
+			IfMatch: r.Header.Get("If-Match"),
+			Reason:  r.Header.Get("X-Change-Reason"),
+			Author:  principal.Email,
+		})
+	}
+}
+
+func (m *DeleteMovieImage) handle(ctx context.Context, r DeleteMovieImageRequest) (*DeleteMovieImageResponse, error) {
+	movie, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := &movie.Images.Poster
+	if r.Kind == artwork.Backdrop {
+		slot = &movie.Images.Backdrop
+	}
+	if *slot == nil {
+		return nil, errors.NewNotFoundError("movie has no "+r.Kind, "movie without "+r.Kind)
+	}
+
+	err = m.repo(ctx, r.ID, r.Kind, cas)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	dropImage(ctx, m.Store, r.ID, *slot)
+	*slot = nil
+
+	m.Hooks.saved(ctx, *movie)
+
+	if r.Reason == "" {
+		r.Reason = r.Kind + " deleted"
+	}
+
+	m.Revisions.record(*movie, domain.ActionUpdate, r.Author, r.Reason)
+
+	return &DeleteMovieImageResponse{}, nil
+}
+
+func (m *DeleteMovieImage) repo(_ context.Context, id int, kind string, cas uint64) error {
+	_, err := m.Repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(id), []gocb.MutateInSpec{
+		gocb.RemoveSpec(imageField(kind), nil),
+	}, &gocb.MutateInOptions{Cas: gocb.Cas(cas), Timeout: 5 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
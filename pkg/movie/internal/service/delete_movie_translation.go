@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type DeleteMovieTranslation struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type DeleteMovieTranslationRequest struct {
+	ID      int    `path:"id"`
+	Locale  string `path:"locale" description:"language tag of the translation, like pt-BR"`
+	IfMatch string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason  string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author  string
+}
+
+type DeleteMovieTranslationResponse struct{}
+
+func NewDeleteMovieTranslation(repo *gocb.Bucket, requireIfMatch bool, hooks Hooks, revisions *Revisions) *DeleteMovieTranslation {
+	return &DeleteMovieTranslation{Repo: repo, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *DeleteMovieTranslation) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Movie Translation",
+		Description: "Delete the translation of the movie texts to the locale",
+		Method:      http.MethodDelete,
+		Path:        "/v1/movies/{id}/translations/{locale}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteMovieTranslationRequest{},
+		Response:    DeleteMovieTranslationResponse{},
+	}
+}
+
+func (m *DeleteMovieTranslation) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		tag, err := parseLocale(chi.URLParam(r, "locale"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, DeleteMovieTranslationRequest{
+			ID:      id,
+			Locale:  tag,
+			IfMatch: r.Header.Get("If-Match"),
+			Reason:  r.Header.Get("X-Change-Reason"),
+			Author:  principal.Email,
+		})
+	}
+}
+
+func (m *DeleteMovieTranslation) handle(ctx context.Context, r DeleteMovieTranslationRequest) (*DeleteMovieTranslationResponse, error) {
+	movie, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := movie.Translations[r.Locale]; !ok {
+		return nil, errors.NewNotFoundError("movie has no "+r.Locale+" translation", "translation "+r.Locale)
+	}
+
+	err = m.repo(ctx, r.ID, r.Locale, cas)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	delete(movie.Translations, r.Locale)
+
+	m.Hooks.saved(ctx, *movie)
+
+	if r.Reason == "" {
+		r.Reason = "translation " + r.Locale + " deleted"
+	}
+
+	m.Revisions.record(*movie, domain.ActionUpdate, r.Author, r.Reason)
+
+	return &DeleteMovieTranslationResponse{}, nil
+}
+
+func (m *DeleteMovieTranslation) repo(_ context.Context, id int, tag string, cas uint64) error {
+	_, err := m.Repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(id), []gocb.MutateInSpec{
+		gocb.RemoveSpec(translationPath(tag), nil),
+	}, &gocb.MutateInOptions{Cas: gocb.Cas(cas), Timeout: 5 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type DeleteMyMovieReview struct {
+	Cluster *gocb.Cluster
+	Repo    *gocb.Bucket
+}
+
+type DeleteMyMovieReviewRequest struct {
+	ID     int `path:"id"`
+	Author string
+}
+
+type DeleteMyMovieReviewResponse struct{}
+
+func NewDeleteMyMovieReview(cluster *gocb.Cluster, repo *gocb.Bucket) *DeleteMyMovieReview {
+	return &DeleteMyMovieReview{Cluster: cluster, Repo: repo}
+}
+
+func (m *DeleteMyMovieReview) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete My Movie Review",
+		Description: "Delete the rating and review of the caller for the movie",
+		Method:      http.MethodDelete,
+		Path:        "/v1/movies/{id}/reviews/mine",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteMyMovieReviewRequest{},
+		Response:    DeleteMyMovieReviewResponse{},
+	}
+}
+
+func (m *DeleteMyMovieReview) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, DeleteMyMovieReviewRequest{ID: id, Author: principal.Email})
+	}
+}
+
+func (m *DeleteMyMovieReview) handle(_ context.Context, r DeleteMyMovieReviewRequest) (*DeleteMyMovieReviewResponse, error) {
+	_, err := saveReview(m.Cluster, m.Repo, r.ID, reviewID(r.ID, r.Author), func(prev *domain.Review) (*domain.Review, error) {
+		if prev == nil {
+			return nil, errors.NewNotFoundError("review not found", "no review of the author")
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &DeleteMyMovieReviewResponse{}, nil
+}
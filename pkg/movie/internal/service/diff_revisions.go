@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/jsonpatch"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type DiffRevisions struct {
+	Repo *gocb.Bucket
+}
+
+type DiffRevisionsRequest struct {
+	ID    int `path:"id"`
+	From  int `query:"from" required:"true" description:"revision to diff from"`
+	To    int `query:"to" required:"true" description:"revision to diff to"`
+	Admin bool
+}
+
+type DiffRevisionsResponse struct {
+	From  int                   `json:"from"`
+	To    int                   `json:"to"`
+	Patch []jsonpatch.Operation `json:"patch"`
+}
+
+func NewDiffRevisions(repo *gocb.Bucket) *DiffRevisions {
+	return &DiffRevisions{Repo: repo}
+}
+
+func (m *DiffRevisions) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Diff Revisions",
+		Description: "Get JSON Patch (RFC 6902) turning a movie revision into another one",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/revisions/diff",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DiffRevisionsRequest{},
+		Response:    DiffRevisionsResponse{},
+	}
+}
+
+func (m *DiffRevisions) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		from, err := strconv.Atoi(r.URL.Query().Get("from"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("from must be integer", errors.Wrap(err, "from conversion").Error())
+		}
+
+		to, err := strconv.Atoi(r.URL.Query().Get("to"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("to must be integer", errors.Wrap(err, "to conversion").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, DiffRevisionsRequest{ID: id, From: from, To: to, Admin: principal.IsAdmin()})
+	}
+}
+
+func (m *DiffRevisions) handle(_ context.Context, r DiffRevisionsRequest) (*DiffRevisionsResponse, error) {
+	if err := checkRevisionAccess(m.Repo, r.ID, r.Admin); err != nil {
+		return nil, err
+	}
+
+	from, err := getRevision(m.Repo, r.ID, r.From)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	to, err := getRevision(m.Repo, r.ID, r.To)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	patch, err := jsonpatch.Diff(from.Movie, to.Movie)
+	if err != nil {
+		return nil, errors.NewInternalServerError(errors.Wrap(err, "revision diff").Error())
+	}
+
+	if patch == nil {
+		patch = []jsonpatch.Operation{}
+	}
+	return &DiffRevisionsResponse{From: r.From, To: r.To, Patch: patch}, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type FlagMovieReview struct {
+	Cluster *gocb.Cluster
+	Repo    *gocb.Bucket
+}
+
+type FlagMovieReviewRequest struct {
+	ID       int    `path:"id"`
+	ReviewID string `path:"reviewId"`
+	Author   string
+	Reason   string `json:"reason" description:"why the review should be moderated"`
+}
+
+type FlagMovieReviewResponse struct{}
+
+func NewFlagMovieReview(cluster *gocb.Cluster, repo *gocb.Bucket) *FlagMovieReview {
+	return &FlagMovieReview{Cluster: cluster, Repo: repo}
+}
+
+func (m *FlagMovieReview) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Flag Movie Review",
+		Description: "Report a review to the moderators, reviews flagged by three users are hidden until moderated",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies/{id}/reviews/{reviewId}/flags",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     FlagMovieReviewRequest{},
+		Response:    FlagMovieReviewResponse{},
+	}
+}
+
+func (m *FlagMovieReview) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		req := FlagMovieReviewRequest{ID: id, ReviewID: chi.URLParam(r, "reviewId")}
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "flag body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+		req.Author = principal.Email
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+func (m *FlagMovieReview) handle(_ context.Context, r FlagMovieReviewRequest) (*FlagMovieReviewResponse, error) {
+	reason := strings.TrimSpace(r.Reason)
+	if reason == "" || len(reason) > 500 {
+		return nil, errors.NewBadRequestError("reason must be between 1 and 500 characters", "flag reason length")
+	}
+
+	_, err := saveReview(m.Cluster, m.Repo, r.ID, r.ReviewID, func(prev *domain.Review) (*domain.Review, error) {
+		if prev == nil || prev.MovieID != r.ID {
+			return nil, errors.NewNotFoundError("review not found", "no review "+r.ReviewID)
+		}
+		if strings.EqualFold(prev.Author, r.Author) {
+			return nil, errors.NewBadRequestError("own review cannot be flagged", "flag of own review")
+		}
+		for _, f := range prev.Flags {
+			if strings.EqualFold(f.Author, r.Author) {
+				return nil, errors.NewConflictError("review is already flagged", "duplicate flag")
+			}
+		}
+
+		next := *prev
+		next.Flags = append(append([]domain.Flag{}, prev.Flags...), domain.Flag{
+			Author:    r.Author,
+			Reason:    reason,
+			CreatedAt: time.Now().UTC(),
+		})
+		if len(next.Flags) >= hideFlagCount {
+			next.Hidden = true
+		}
+		return &next, nil
+	})
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &FlagMovieReviewResponse{}, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+type GetFlaggedReviews struct {
+	Repo *gocb.Bucket
+}
+
+type GetFlaggedReviewsRequest struct {
+	pagination.Request
+}
+
+type GetFlaggedReviewsResponse struct {
+	TotalCount int              `json:"totalCount"`
+	Reviews    []domain.Review  `json:"reviews"`
+	Pagination pagination.Model `json:"pagination"`
+}
+
+func NewGetFlaggedReviews(repo *gocb.Bucket) *GetFlaggedReviews {
+	return &GetFlaggedReviews{Repo: repo}
+}
+
+func (m *GetFlaggedReviews) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Flagged Reviews",
+		Description: "Get reviews waiting for moderation, most flagged first",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/reviews/flagged",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     GetFlaggedReviewsRequest{},
+		Response:    GetFlaggedReviewsResponse{},
+	}
+}
+
+func (m *GetFlaggedReviews) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		var req GetFlaggedReviewsRequest
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetFlaggedReviews) handle(ctx context.Context, r GetFlaggedReviewsRequest) (*GetFlaggedReviewsResponse, error) {
+	total, err := m.count(ctx)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	reviews, err := m.repo(ctx, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetFlaggedReviewsResponse{TotalCount: total, Reviews: reviews, Pagination: p}, nil
+}
+
+func (m *GetFlaggedReviews) count(_ context.Context) (int, error) {
+	query := "SELECT RAW COUNT(*) FROM `movie`.movie.review AS r WHERE ARRAY_LENGTH(r.Flags) > 0"
+
+	return countQuery(m.Repo, query)
+}
+
+func (m *GetFlaggedReviews) repo(_ context.Context, offset, limit int) ([]domain.Review, error) {
+	query := "SELECT r.* FROM `movie`.movie.review AS r WHERE ARRAY_LENGTH(r.Flags) > 0 " +
+		"ORDER BY ARRAY_LENGTH(r.Flags) DESC, r.UpdatedAt OFFSET $1 LIMIT $2"
+
+	rows, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{offset, limit},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	reviews := []domain.Review{}
+
+	for rows.Next() {
+		var review domain.Review
+
+		err := rows.Row(&review)
+		if err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return reviews, nil
+}
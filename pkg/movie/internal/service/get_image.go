@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/blob"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/go-chi/chi"
+)
+
+// GetImage serves the stored images when no CDN or S3 endpoint serves them. Every upload is
+// stored under a new key, so the images are cached for good.
+type GetImage struct {
+	Store blob.Store
+}
+
+type GetImageRequest struct {
+	Key string `path:"*" description:"key of the image, as in the image URLs of the movie"`
+}
+
+type GetImageResponse struct{}
+
+func NewGetImage(store blob.Store) *GetImage {
+	return &GetImage{Store: store}
+}
+
+func (m *GetImage) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Image",
+		Description: "Get a stored movie image",
+		Method:      http.MethodGet,
+		Path:        "/v1/images/*",
+		Headers:     map[string]string{"Cache-Control": "images never change"},
+		Middlewares: []func(http.Handler) http.Handler{},
+		Handler:     m.endpoint(ctx),
+		Request:     GetImageRequest{},
+		Response:    GetImageResponse{},
+	}
+}
+
+func (m *GetImage) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		obj, err := m.Store.Get(r.Context(), chi.URLParam(r, "*"))
+		if err != nil {
+			return nil, errors.Translate(errors.Wrap(err, "blob get"))
+		}
+		defer obj.Body.Close()
+
+		w.Header().Set("Content-Type", obj.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+
+		if _, err := io.Copy(w, obj.Body); err != nil {
+			return nil, errors.NewInternalServerError(errors.Wrap(err, "image copy").Error())
+		}
+		return nil, nil
+	}
+}
@@ -2,40 +2,71 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/catalog"
 	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/fieldset"
+	"github.com/3n0ugh/allotropes/internal/locale"
 	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
 	"github.com/couchbase/gocb/v2"
 	"github.com/go-chi/chi"
 )
 
 type GetMovieByID struct {
-	Repo *gocb.Bucket
+	Repo        *gocb.Bucket
+	Prior       *RatingPrior
+	Collections catalog.Collections
 }
 
 type GetMovieByIDRequest struct {
-	ID int `path:"id"`
+	fieldset.Query
+	ID             int    `path:"id"`
+	IfNoneMatch    string `header:"If-None-Match" description:"entity tag of the cached movie"`
+	AcceptLanguage string `header:"Accept-Language" description:"preferred locales of the title and stories, the original language when none is translated"`
 }
 
 type GetMovieByIDResponse struct {
-	Movie domain.Movie `json:"movie"`
+	Movie      domain.Movie        `json:"movie"`
+	Score      domain.Score        `json:"score"`
+	Collection *catalog.Collection `json:"collection" description:"collection of the movie, null when it belongs to none"`
+
+	etag        string
+	notModified bool
+	fields      fieldset.Set
+	language    string
+}
+
+// MarshalJSON leaves out the movie fields which are not selected.
+func (r GetMovieByIDResponse) MarshalJSON() ([]byte, error) {
+	movie, err := r.fields.Project(r.Movie)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Movie      any                 `json:"movie"`
+		Score      domain.Score        `json:"score"`
+		Collection *catalog.Collection `json:"collection"`
+	}{movie, r.Score, r.Collection})
 }
 
-func NewGetMovieByID(repo *gocb.Bucket) *GetMovieByID {
-	return &GetMovieByID{Repo: repo}
+func NewGetMovieByID(repo *gocb.Bucket, prior *RatingPrior, collections catalog.Collections) *GetMovieByID {
+	return &GetMovieByID{Repo: repo, Prior: prior, Collections: collections}
 }
 
 func (m *GetMovieByID) Route(ctx context.Context) application.Route {
 	return application.Route{
 		Name:        "Get Movie",
 		Description: "Get movie by id",
-		Method:      http.MethodPut,
+		Method:      http.MethodGet,
 		Path:        "/v1/movies/{id}",
-		Headers:     map[string]string{},
+		Headers:     map[string]string{"ETag": "entity tag of the representation, accepted by If-Match on writes", "Content-Language": "locale of the title and stories"},
 		Handler:     m.endpoint(ctx),
 		Request:     GetMovieByIDRequest{},
 		Response:    GetMovieByIDResponse{},
@@ -51,31 +82,88 @@ func (m *GetMovieByID) endpoint(ctx context.Context) application.HandlerFunc {
 			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
 		}
 
-		return m.handle(ctx, GetMovieByIDRequest{ID: id})
+		req := GetMovieByIDRequest{
+			ID:             id,
+			IfNoneMatch:    r.Header.Get("If-None-Match"),
+			AcceptLanguage: r.Header.Get("Accept-Language"),
+		}
+		req.Query.Parse(r.URL.Query())
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		writeLanguage(w, res.language)
+		if res.notModified {
+			w.WriteHeader(http.StatusNotModified)
+			return nil, nil
+		}
+
+		return res, nil
 	}
 }
 
+// handle reads only the selected fields, with the original language and the translations when a
+// localized field is selected. The entity tag varies with everything the response holds besides
+// the movie document: the fields, the locale, the score and the collection, on top of the cas
+// value of the movie writes are checked against. They are all read before answering 304.
 func (m *GetMovieByID) handle(ctx context.Context, r GetMovieByIDRequest) (*GetMovieByIDResponse, error) {
-	movie, err := m.repo(r.ID)
+	fields, err := r.Resolve(domain.MovieFields, domain.HeavyMovieFields, "ID")
 	if err != nil {
-		return nil, errors.NewInternalServerError(errors.Wrap(err, "couchbase query").Error())
+		return nil, err
 	}
 
-	return &GetMovieByIDResponse{Movie: *movie}, nil
-}
+	movie, cas, err := m.repo(r.ID, readFields(fields))
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	score, err := getScore(m.Repo, m.Prior, r.ID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	collection, err := m.Collections.OfMovie(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "movie collection"))
+	}
 
-func (m *GetMovieByID) repo(ID int) (*domain.Movie, error) {
-	doc, err := m.Repo.Scope("movie").Collection("movie").Get(strconv.Itoa(ID), &gocb.GetOptions{
-		Timeout: time.Second * 3,
-	})
+	localized, language := movie.Localize(locale.ParseAcceptLanguage(r.AcceptLanguage))
+
+	variant, err := json.Marshal(struct {
+		Score      domain.Score
+		Collection *catalog.Collection
+	}{score, collection})
 	if err != nil {
-		return nil, errors.Wrap(err, "couchbase query")
+		return nil, errors.NewInternalServerError(errors.Wrap(err, "entity tag variant").Error())
 	}
 
-	var movie domain.Movie
-	if err := doc.Content(&movie); err != nil {
-		return nil, errors.Wrap(err, "row parse")
+	tag := etag.FormatVariant(cas, strings.Join(fields, ","), language, string(variant))
+	if r.IfNoneMatch != "" && etag.MatchWeak(r.IfNoneMatch, tag) {
+		return &GetMovieByIDResponse{etag: tag, notModified: true, language: language}, nil
 	}
 
-	return &movie, nil
+	return &GetMovieByIDResponse{Movie: localized, Score: score, Collection: collection, etag: tag, fields: fields, language: language}, nil
+}
+
+// readFields adds the fields localizing the selected ones needs.
+func readFields(fields fieldset.Set) []string {
+	read := append([]string{}, fields...)
+	for _, f := range domain.LocalizedMovieFields {
+		if containsString(fields, f) {
+			for _, extra := range []string{"Language", "Translations"} {
+				if !containsString(read, extra) {
+					read = append(read, extra)
+				}
+			}
+			break
+		}
+	}
+	return read
+}
+
+func (m *GetMovieByID) repo(ID int, fields []string) (*domain.Movie, uint64, error) {
+	return getMovieFields(m.Repo, ID, fields)
 }
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetMovieCast struct {
+	Repo *gocb.Bucket
+}
+
+type GetMovieCastRequest struct {
+	ID int `path:"id"`
+}
+
+type GetMovieCastResponse struct {
+	Cast []domain.Cast `json:"cast"`
+
+	etag string
+}
+
+func NewGetMovieCast(repo *gocb.Bucket) *GetMovieCast {
+	return &GetMovieCast{Repo: repo}
+}
+
+func (m *GetMovieCast) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Movie Cast",
+		Description: "Get cast of the movie ordered by billing",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/cast",
+		Headers:     map[string]string{"ETag": "entity tag of the movie"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetMovieCastRequest{},
+		Response:    GetMovieCastResponse{},
+	}
+}
+
+func (m *GetMovieCast) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		res, err := m.handle(ctx, GetMovieCastRequest{ID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *GetMovieCast) handle(_ context.Context, r GetMovieCastRequest) (*GetMovieCastResponse, error) {
+	cast, cas, err := getCredits[domain.Cast](m.Repo, r.ID, "Cast")
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	return &GetMovieCastResponse{Cast: orderCast(cast), etag: etag.Format(cas)}, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetMovieCrew struct {
+	Repo *gocb.Bucket
+}
+
+type GetMovieCrewRequest struct {
+	ID int `path:"id"`
+}
+
+type GetMovieCrewResponse struct {
+	Crew []domain.Crew `json:"crew"`
+
+	etag string
+}
+
+func NewGetMovieCrew(repo *gocb.Bucket) *GetMovieCrew {
+	return &GetMovieCrew{Repo: repo}
+}
+
+func (m *GetMovieCrew) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Movie Crew",
+		Description: "Get crew of the movie",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/crew",
+		Headers:     map[string]string{"ETag": "entity tag of the movie"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetMovieCrewRequest{},
+		Response:    GetMovieCrewResponse{},
+	}
+}
+
+func (m *GetMovieCrew) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		res, err := m.handle(ctx, GetMovieCrewRequest{ID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *GetMovieCrew) handle(_ context.Context, r GetMovieCrewRequest) (*GetMovieCrewResponse, error) {
+	crew, cas, err := getCredits[domain.Crew](m.Repo, r.ID, "Crew")
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	if crew == nil {
+		crew = []domain.Crew{}
+	}
+	return &GetMovieCrewResponse{Crew: crew, etag: etag.Format(cas)}, nil
+}
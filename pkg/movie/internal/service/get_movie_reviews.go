@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetMovieReviews struct {
+	Repo  *gocb.Bucket
+	Prior *RatingPrior
+}
+
+type GetMovieReviewsRequest struct {
+	ID int `path:"id"`
+	pagination.Request
+}
+
+// PublicReview is a review as listed to everyone, without its author and flags.
+type PublicReview struct {
+	ID        string    `json:"ID"`
+	Rating    int       `json:"Rating"`
+	Title     string    `json:"Title,omitempty"`
+	Body      string    `json:"Body,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+type GetMovieReviewsResponse struct {
+	Score      domain.Score     `json:"score"`
+	TotalCount int              `json:"totalCount"`
+	Reviews    []PublicReview   `json:"reviews"`
+	Pagination pagination.Model `json:"pagination"`
+}
+
+func NewGetMovieReviews(repo *gocb.Bucket, prior *RatingPrior) *GetMovieReviews {
+	return &GetMovieReviews{Repo: repo, Prior: prior}
+}
+
+func (m *GetMovieReviews) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Movie Reviews",
+		Description: "Get the score and the visible reviews of the movie, newest first",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/reviews",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetMovieReviewsRequest{},
+		Response:    GetMovieReviewsResponse{},
+	}
+}
+
+func (m *GetMovieReviews) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		req := GetMovieReviewsRequest{ID: id}
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetMovieReviews) handle(ctx context.Context, r GetMovieReviewsRequest) (*GetMovieReviewsResponse, error) {
+	_, _, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	score, err := getScore(m.Repo, m.Prior, r.ID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	total, err := m.count(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	reviews, err := m.repo(ctx, r.ID, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetMovieReviewsResponse{Score: score, TotalCount: total, Reviews: reviews, Pagination: p}, nil
+}
+
+func (m *GetMovieReviews) count(_ context.Context, id int) (int, error) {
+	query := "SELECT RAW COUNT(*) FROM `movie`.movie.review AS r WHERE r.MovieID = $1 AND r.Hidden = false"
+
+	return countQuery(m.Repo, query, id)
+}
+
+func (m *GetMovieReviews) repo(_ context.Context, id, offset, limit int) ([]PublicReview, error) {
+	query := "SELECT r.ID, r.Rating, r.Title, r.Body, r.CreatedAt, r.UpdatedAt FROM `movie`.movie.review AS r " +
+		"WHERE r.MovieID = $1 AND r.Hidden = false ORDER BY r.UpdatedAt DESC, r.ID OFFSET $2 LIMIT $3"
+
+	rows, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{id, offset, limit},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	reviews := []PublicReview{}
+
+	for rows.Next() {
+		var review PublicReview
+
+		err := rows.Row(&review)
+		if err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return reviews, nil
+}
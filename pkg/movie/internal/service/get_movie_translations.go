@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetMovieTranslations struct {
+	Repo *gocb.Bucket
+}
+
+type GetMovieTranslationsRequest struct {
+	ID int `path:"id"`
+}
+
+type GetMovieTranslationsResponse struct {
+	Language     string                        `json:"language"`
+	Translations map[string]domain.Translation `json:"translations"`
+
+	etag string
+}
+
+func NewGetMovieTranslations(repo *gocb.Bucket) *GetMovieTranslations {
+	return &GetMovieTranslations{Repo: repo}
+}
+
+func (m *GetMovieTranslations) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Movie Translations",
+		Description: "Get translations of the movie by locale, along with its original language",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/translations",
+		Headers:     map[string]string{"ETag": "entity tag of the movie"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetMovieTranslationsRequest{},
+		Response:    GetMovieTranslationsResponse{},
+	}
+}
+
+func (m *GetMovieTranslations) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		res, err := m.handle(ctx, GetMovieTranslationsRequest{ID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *GetMovieTranslations) handle(_ context.Context, r GetMovieTranslationsRequest) (*GetMovieTranslationsResponse, error) {
+	movie, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	translations := movie.Translations
+	if translations == nil {
+		translations = map[string]domain.Translation{}
+	}
+
+	return &GetMovieTranslationsResponse{Language: movie.Language, Translations: translations, etag: etag.Format(cas)}, nil
+}
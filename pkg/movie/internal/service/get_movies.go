@@ -2,42 +2,85 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
-	"strconv"
+	"strings"
 
 	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/cursor"
 	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/fieldset"
+	"github.com/3n0ugh/allotropes/internal/locale"
 	"github.com/3n0ugh/allotropes/internal/pagination"
 	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
-	"github.com/couchbase/gocb/v2"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/repository"
 )
 
 type GetMovies struct {
-	Repo *gocb.Bucket
+	Repo         repository.Movies
+	CursorSecret string
+	Batch        *BatchGetMovies
 }
 
 type GetMoviesRequest struct {
-	Page int `query:"page"`
-	Size int `query:"size"`
+	pagination.CursorRequest
+	fieldset.Query
+	MovieFilter
+	Sort   string `query:"sort" description:"comma separated fields, prefixed with - for descending: ID, Title, ReleaseDate, Runtime, Language"`
+	Facets string `query:"facets" description:"comma separated facets counted over the filtered movies: Genres, Language, Country, Company, Decade"`
+	IDs    string `query:"ids" description:"comma separated movie ids, answered like POST /v1/movies:batchGet instead of a page"`
+
+	AcceptLanguage string `header:"Accept-Language" description:"preferred locales of the titles and stories, the original languages when none is translated"`
 }
 
+// sortFields are the movie fields a listing can be sorted by.
+var sortFields = []string{"ID", "Title", "ReleaseDate", "Runtime", "Language"}
+
 type GetMoviesResponse struct {
 	TotalCount int              `json:"totalCount"`
 	Movies     []domain.Movie   `json:"movies"`
 	Pagination pagination.Model `json:"pagination"`
+	Facets     *MovieFacets     `json:"facets,omitempty"`
+
+	fields   fieldset.Set
+	language string
+}
+
+// MarshalJSON leaves out the movie fields which are not selected.
+func (r GetMoviesResponse) MarshalJSON() ([]byte, error) {
+	movies, err := r.fields.Project(r.Movies)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		TotalCount int              `json:"totalCount"`
+		Movies     any              `json:"movies"`
+		Pagination pagination.Model `json:"pagination"`
+		Facets     *MovieFacets     `json:"facets,omitempty"`
+	}{r.TotalCount, movies, r.Pagination, r.Facets})
+}
+
+// moviesCursor is the payload of the cursor tokens. The sort is kept to reject
+// cursors issued for another order, the total count of the first page is carried
+// along so that cursor pages do not count the movies again.
+type moviesCursor struct {
+	Sort  string         `json:"s"`
+	Key   repository.Key `json:"k"`
+	Total int            `json:"t"`
 }
 
-func NewGetMovies(repo *gocb.Bucket) *GetMovies {
-	return &GetMovies{Repo: repo}
+func NewGetMovies(repo repository.Movies, cursorSecret string, batch *BatchGetMovies) *GetMovies {
+	return &GetMovies{Repo: repo, CursorSecret: cursorSecret, Batch: batch}
 }
 
 func (m *GetMovies) Route(ctx context.Context) application.Route {
 	return application.Route{
 		Name:        "Get Movies",
-		Description: "Get movies by page and page size",
+		Description: "Get movies by page and page size, or by cursor",
 		Method:      http.MethodGet,
 		Path:        "/v1/movies",
-		Headers:     map[string]string{},
+		Headers:     map[string]string{"Link": "first, prev, next and last page links", "Content-Language": "locales of the titles and stories"},
 		Handler:     m.endpoint(ctx),
 		Request:     GetMoviesRequest{},
 		Response:    GetMoviesResponse{},
@@ -46,57 +89,150 @@ func (m *GetMovies) Route(ctx context.Context) application.Route {
 
 func (m *GetMovies) endpoint(ctx context.Context) application.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		if ids := r.URL.Query().Get("ids"); ids != "" {
+			parsed, err := parseIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			res, err := m.Batch.handle(ctx, BatchGetMoviesRequest{IDs: parsed, AcceptLanguage: r.Header.Get("Accept-Language")})
+			if err != nil {
+				return nil, err
+			}
+
+			writeLanguage(w, res.language)
+			return res, nil
+		}
+
 		var req GetMoviesRequest
 
-		if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-			req.Page = p
+		if err := req.CursorRequest.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		if err := req.MovieFilter.Parse(r.URL.Query()); err != nil {
+			return nil, err
 		}
 
-		if p, err := strconv.Atoi(r.URL.Query().Get("pageSize")); err == nil {
-			req.Size = p
+		req.Query.Parse(r.URL.Query())
+		req.Sort = r.URL.Query().Get("sort")
+		req.Facets = r.URL.Query().Get("facets")
+		req.AcceptLanguage = r.Header.Get("Accept-Language")
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
 		}
 
-		return m.handle(ctx, req)
+		res.Pagination.Write(w, r)
+		writeLanguage(w, res.language)
+		return res, nil
 	}
 }
 
 func (m *GetMovies) handle(ctx context.Context, r GetMoviesRequest) (*GetMoviesResponse, error) {
-	if r.Size > 20 {
-		r.Size = 20
+	sort, err := parseSort(r.Sort)
+	if err != nil {
+		return nil, err
 	}
 
-	movies, err := m.repo(ctx, r.Page, r.Size)
+	fields, err := r.Resolve(domain.MovieFields, domain.HeavyMovieFields, "ID")
 	if err != nil {
-		return nil, errors.NewInternalServerError(errors.Wrap(err, "couchbase query").Error())
+		return nil, err
 	}
-	return &GetMoviesResponse{Movies: movies}, nil
-}
 
-func (m *GetMovies) repo(ctx context.Context, page, pageSize int) ([]domain.Movie, error) {
-	query := "SELECT movie FROM `movie`.movie.movie OFFSET $1 LIMIT $2"
+	facets, err := parseFacets(r.Facets)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{
-		PositionalParameters: []interface{}{page * pageSize, pageSize},
-	})
+	preferred := locale.ParseAcceptLanguage(r.AcceptLanguage)
 
-	var movies []domain.Movie
+	q := repository.Query{
+		Filter: r.Expr(),
+		Fields: localizedFields(fields, preferred),
+		Sort:   sort,
+		Offset: r.Offset(),
+		Limit:  r.Size,
+	}
 
-	for rows.Next() {
-		var movie struct {
-			M domain.Movie `json:"movie"`
+	var total int
+	if r.Cursor != "" {
+		var c moviesCursor
+		if err := cursor.Decode(m.CursorSecret, r.Cursor, &c); err != nil || c.Sort != q.SortString() {
+			return nil, errors.NewBadRequestError("invalid cursor", "cursor decode")
 		}
-
-		err := rows.Row(&movie)
+		q.After, total = &c.Key, c.Total
+	} else {
+		total, err = m.Repo.Count(ctx, q)
 		if err != nil {
-			return nil, errors.Wrap(err, "row parse")
+			return nil, errors.Translate(errors.Wrap(err, "movie count"))
 		}
+	}
 
-		movies = append(movies, movie.M)
+	p := pagination.NewCursor(r.CursorRequest, total, "")
+	if err := p.Validate(); err != nil {
+		return nil, err
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, errors.Wrap(err, "rows")
+	movies, err := m.Repo.List(ctx, q)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "movie list"))
 	}
 
-	return movies, nil
+	p.NextCursor, err = m.nextCursor(q, movies, total)
+	if err != nil {
+		return nil, errors.NewInternalServerError(errors.Wrap(err, "next cursor").Error())
+	}
+
+	language := localize(movies, preferred)
+
+	res := &GetMoviesResponse{TotalCount: total, Movies: movies, Pagination: p, fields: fields, language: language}
+	if len(facets) > 0 {
+		if res.Facets, err = countFacets(ctx, m.Repo, q, facets); err != nil {
+			return nil, errors.Translate(err)
+		}
+	}
+	return res, nil
+}
+
+// nextCursor returns the cursor continuing after the last movie of a full page.
+func (m *GetMovies) nextCursor(q repository.Query, movies []domain.Movie, total int) (string, error) {
+	if len(movies) < q.Limit {
+		return "", nil
+	}
+
+	key, err := q.KeyOf(movies[len(movies)-1])
+	if err != nil {
+		return "", err
+	}
+
+	return cursor.Encode(m.CursorSecret, moviesCursor{Sort: q.SortString(), Key: key, Total: total})
+}
+
+// parseSort parses a sort like "-ReleaseDate,Title". The listing is sorted by ID by default.
+func parseSort(s string) ([]repository.Sort, error) {
+	if s == "" {
+		return []repository.Sort{{Field: "ID"}}, nil
+	}
+
+	var sort []repository.Sort
+	for _, field := range strings.Split(s, ",") {
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+
+		if !isSortField(field) {
+			return nil, errors.NewBadRequestError("sort field must be one of "+strings.Join(sortFields, ", "), "sort field "+field)
+		}
+		sort = append(sort, repository.Sort{Field: field, Desc: desc})
+	}
+	return sort, nil
+}
+
+func isSortField(field string) bool {
+	for _, f := range sortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetMyMovieReview struct {
+	Repo *gocb.Bucket
+}
+
+type GetMyMovieReviewRequest struct {
+	ID     int `path:"id"`
+	Author string
+}
+
+type GetMyMovieReviewResponse struct {
+	Review domain.Review `json:"review"`
+}
+
+func NewGetMyMovieReview(repo *gocb.Bucket) *GetMyMovieReview {
+	return &GetMyMovieReview{Repo: repo}
+}
+
+func (m *GetMyMovieReview) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get My Movie Review",
+		Description: "Get the rating and review of the caller for the movie",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/reviews/mine",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     GetMyMovieReviewRequest{},
+		Response:    GetMyMovieReviewResponse{},
+	}
+}
+
+func (m *GetMyMovieReview) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, GetMyMovieReviewRequest{ID: id, Author: principal.Email})
+	}
+}
+
+func (m *GetMyMovieReview) handle(ctx context.Context, r GetMyMovieReviewRequest) (*GetMyMovieReviewResponse, error) {
+	review, err := m.repo(ctx, reviewID(r.ID, r.Author))
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetMyMovieReviewResponse{Review: *review}, nil
+}
+
+func (m *GetMyMovieReview) repo(_ context.Context, id string) (*domain.Review, error) {
+	doc, err := m.Repo.Scope("movie").Collection("review").Get(id, &gocb.GetOptions{Timeout: 3 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	var review domain.Review
+	if err := doc.Content(&review); err != nil {
+		return nil, errors.Wrap(err, "row parse")
+	}
+	return &review, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetRevision struct {
+	Repo *gocb.Bucket
+}
+
+type GetRevisionRequest struct {
+	ID       int `path:"id"`
+	Revision int `path:"rev"`
+	Admin    bool
+}
+
+type GetRevisionResponse struct {
+	Revision domain.Revision `json:"revision"`
+}
+
+func NewGetRevision(repo *gocb.Bucket) *GetRevision {
+	return &GetRevision{Repo: repo}
+}
+
+func (m *GetRevision) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Revision",
+		Description: "Get revision of the movie with its snapshot",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/revisions/{rev}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     GetRevisionRequest{},
+		Response:    GetRevisionResponse{},
+	}
+}
+
+func (m *GetRevision) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		rev, err := strconv.Atoi(chi.URLParam(r, "rev"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("rev must be integer", errors.Wrap(err, "rev conversion").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, GetRevisionRequest{ID: id, Revision: rev, Admin: principal.IsAdmin()})
+	}
+}
+
+func (m *GetRevision) handle(_ context.Context, r GetRevisionRequest) (*GetRevisionResponse, error) {
+	if err := checkRevisionAccess(m.Repo, r.ID, r.Admin); err != nil {
+		return nil, err
+	}
+
+	rev, err := getRevision(m.Repo, r.ID, r.Revision)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetRevisionResponse{Revision: *rev}, nil
+}
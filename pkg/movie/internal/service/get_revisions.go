@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetRevisions struct {
+	Repo *gocb.Bucket
+}
+
+type GetRevisionsRequest struct {
+	ID    int `path:"id"`
+	Admin bool
+	pagination.Request
+}
+
+type GetRevisionsResponse struct {
+	TotalCount int               `json:"totalCount"`
+	Revisions  []domain.Revision `json:"revisions"`
+	Pagination pagination.Model  `json:"pagination"`
+}
+
+func NewGetRevisions(repo *gocb.Bucket) *GetRevisions {
+	return &GetRevisions{Repo: repo}
+}
+
+func (m *GetRevisions) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Revisions",
+		Description: "Get revisions of the movie without snapshots, newest first",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/revisions",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     GetRevisionsRequest{},
+		Response:    GetRevisionsResponse{},
+	}
+}
+
+func (m *GetRevisions) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		req := GetRevisionsRequest{ID: id, Admin: principal.IsAdmin()}
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetRevisions) handle(ctx context.Context, r GetRevisionsRequest) (*GetRevisionsResponse, error) {
+	if err := checkRevisionAccess(m.Repo, r.ID, r.Admin); err != nil {
+		return nil, err
+	}
+
+	total, err := m.count(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	revisions, err := m.repo(ctx, r.ID, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetRevisionsResponse{TotalCount: total, Revisions: revisions, Pagination: p}, nil
+}
+
+func (m *GetRevisions) count(_ context.Context, id int) (int, error) {
+	query := "SELECT RAW COUNT(*) FROM `movie`.movie.revision AS r WHERE r.MovieID = $1"
+
+	return countQuery(m.Repo, query, id)
+}
+
+func (m *GetRevisions) repo(_ context.Context, id, offset, limit int) ([]domain.Revision, error) {
+	query := "SELECT r.MovieID, r.Revision, r.Action, r.Author, r.Reason, r.CreatedAt FROM `movie`.movie.revision AS r " +
+		"WHERE r.MovieID = $1 ORDER BY r.Revision DESC OFFSET $2 LIMIT $3"
+
+	rows, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{id, offset, limit},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	revisions := []domain.Revision{}
+
+	for rows.Next() {
+		var rev domain.Revision
+
+		err := rows.Row(&rev)
+		if err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/similar"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 20
+)
+
+type GetSimilarMovies struct {
+	Repo   *gocb.Bucket
+	Index  *similar.Index
+	People People
+}
+
+type GetSimilarMoviesRequest struct {
+	ID    int `path:"id"`
+	Limit int `query:"limit" description:"number of similar movies, at most 20"`
+}
+
+// SimilarMovie is a neighbour of the movie with its reasons phrased.
+type SimilarMovie struct {
+	similar.Neighbour
+	Because []string `json:"because"`
+}
+
+type GetSimilarMoviesResponse struct {
+	Similar []SimilarMovie `json:"similar"`
+}
+
+func NewGetSimilarMovies(repo *gocb.Bucket, index *similar.Index, people People) *GetSimilarMovies {
+	return &GetSimilarMovies{Repo: repo, Index: index, People: people}
+}
+
+func (m *GetSimilarMovies) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Similar Movies",
+		Description: "Get the movies sharing the most genres, keywords, cast, crew and companies with the movie, and what they share",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/{id}/similar",
+		Handler:     m.endpoint(ctx),
+		Request:     GetSimilarMoviesRequest{},
+		Response:    GetSimilarMoviesResponse{},
+	}
+}
+
+// Job rebuilds the neighbours, which drift from the exact ones as movie writes change how
+// common the features are.
+func (m *GetSimilarMovies) Job() application.Job {
+	return application.Job{
+		Name:     "Similar Movies",
+		Interval: time.Hour,
+		Run:      m.Index.Rebuild,
+	}
+}
+
+func (m *GetSimilarMovies) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		req := GetSimilarMoviesRequest{ID: id, Limit: defaultSimilarLimit}
+
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, err := strconv.Atoi(l)
+			if err != nil || limit < 1 || limit > maxSimilarLimit {
+				return nil, errors.NewBadRequestError("limit must be an integer between 1 and 20", "limit conversion")
+			}
+			req.Limit = limit
+		}
+
+		return m.handle(ctx, req)
+	}
+}
+
+func (m *GetSimilarMovies) handle(ctx context.Context, r GetSimilarMoviesRequest) (*GetSimilarMoviesResponse, error) {
+	neighbours, ok := m.Index.Similar(r.ID, r.Limit)
+	if !ok {
+		// The movie may be written after the index was loaded but before its hook ran.
+		if _, _, err := getMovie(m.Repo, r.ID); err != nil {
+			return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+		}
+	}
+
+	m.name(ctx, neighbours)
+
+	res := &GetSimilarMoviesResponse{Similar: make([]SimilarMovie, len(neighbours))}
+	for i, n := range neighbours {
+		res.Similar[i] = SimilarMovie{Neighbour: n, Because: make([]string, len(n.Reasons))}
+		for j, reason := range n.Reasons {
+			res.Similar[i].Because[j] = reason.Explain()
+		}
+	}
+	return res, nil
+}
+
+// name fills in the names of the crew the neighbours share, which movies do not carry. Without
+// a directory, or when it fails, the reasons keep the person ids.
+func (m *GetSimilarMovies) name(ctx context.Context, neighbours []similar.Neighbour) {
+	if m.People == nil {
+		return
+	}
+
+	var ids []int
+	for _, n := range neighbours {
+		for _, reason := range n.Reasons {
+			if reason.PersonID != 0 && reason.Value == "" {
+				ids = append(ids, reason.PersonID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	names, err := m.People.Names(ctx, ids)
+	if err != nil {
+		log.Printf("similar movie reasons: %s", err)
+		return
+	}
+
+	for _, n := range neighbours {
+		for j, reason := range n.Reasons {
+			if reason.Value == "" {
+				n.Reasons[j].Value = names[reason.PersonID]
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+type GetTrash struct {
+	Repo *gocb.Bucket
+}
+
+type GetTrashRequest struct {
+	pagination.Request
+}
+
+type GetTrashResponse struct {
+	TotalCount int              `json:"totalCount"`
+	Movies     []domain.Movie   `json:"movies"`
+	Pagination pagination.Model `json:"pagination"`
+}
+
+func NewGetTrash(repo *gocb.Bucket) *GetTrash {
+	return &GetTrash{Repo: repo}
+}
+
+func (m *GetTrash) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Trash",
+		Description: "Get soft deleted movies, most recently deleted first",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/trash",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     GetTrashRequest{},
+		Response:    GetTrashResponse{},
+	}
+}
+
+func (m *GetTrash) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		var req GetTrashRequest
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetTrash) handle(ctx context.Context, r GetTrashRequest) (*GetTrashResponse, error) {
+	total, err := m.count(ctx)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	movies, err := m.repo(ctx, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetTrashResponse{TotalCount: total, Movies: movies, Pagination: p}, nil
+}
+
+func (m *GetTrash) count(_ context.Context) (int, error) {
+	query := "SELECT RAW COUNT(*) FROM `movie`.movie.movie WHERE movie.DeletedAt IS VALUED"
+
+	return countQuery(m.Repo, query)
+}
+
+func (m *GetTrash) repo(_ context.Context, offset, limit int) ([]domain.Movie, error) {
+	query := "SELECT movie FROM `movie`.movie.movie WHERE movie.DeletedAt IS VALUED ORDER BY movie.DeletedAt DESC OFFSET $1 LIMIT $2"
+
+	rows, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{offset, limit},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	movies := []domain.Movie{}
+
+	for rows.Next() {
+		var movie struct {
+			M domain.Movie `json:"movie"`
+		}
+
+		err := rows.Row(&movie)
+		if err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		movies = append(movies, movie.M)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return movies, nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+)
+
+// Hook is notified after a movie write went through, so that read models and indexes
+// derived from the movies stay current. Soft deleted movies are reported as saved.
+type Hook interface {
+	MovieSaved(ctx context.Context, movie domain.Movie) error
+	MoviePurged(ctx context.Context, id int) error
+}
+
+type Hooks []Hook
+
+// saved notifies the hooks and logs their failures.
+func (h Hooks) saved(ctx context.Context, movie domain.Movie) {
+	for _, hook := range h {
+		if err := hook.MovieSaved(ctx, movie); err != nil {
+			log.Printf("movie %d saved hook: %s", movie.ID, err)
+		}
+	}
+}
+
+func (h Hooks) purged(ctx context.Context, id int) {
+	for _, hook := range h {
+		if err := hook.MoviePurged(ctx, id); err != nil {
+			log.Printf("movie %d purged hook: %s", id, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/blob"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/artwork"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+)
+
+// imageField is the sub-document path of the image of the kind.
+func imageField(kind string) string {
+	return "Images." + strings.ToUpper(kind[:1]) + kind[1:]
+}
+
+func parseImageKind(kind string) (string, error) {
+	if _, ok := artwork.Widths[kind]; !ok {
+		return "", errors.NewNotFoundError("image kind must be poster or backdrop", "image kind "+kind)
+	}
+	return kind, nil
+}
+
+// storeImage stores the original and its thumbnails under a new prefix, so that caches of an
+// earlier upload never serve the new one. Blobs stored before a failure are removed again.
+func storeImage(ctx context.Context, store blob.Store, baseURL string, movieID int, kind string, data []byte) (*domain.Image, error) {
+	contentType, err := artwork.Sniff(data)
+	if err != nil {
+		return nil, errors.NewUnsupportedMediaTypeError(err.Error(), errors.Wrap(err, "image sniff").Error())
+	}
+
+	img, err := artwork.Decode(data)
+	if err != nil {
+		return nil, errors.NewBadRequestError("image cannot be decoded: "+err.Error(), errors.Wrap(err, "image").Error())
+	}
+
+	now := time.Now().UTC()
+	prefix := "movies/" + strconv.Itoa(movieID) + "/" + kind + "/" + strconv.FormatInt(now.UnixNano(), 36) + "/"
+	ext := artwork.Extension(contentType)
+
+	image := &domain.Image{
+		ContentType: contentType,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		URL:         baseURL + prefix + "original" + ext,
+		Sizes:       map[string]string{},
+		Prefix:      prefix,
+		UploadedAt:  now,
+	}
+
+	put := func(key string, data []byte) error {
+		if err := store.Put(ctx, key, data, contentType); err != nil {
+			if err := store.DeletePrefix(ctx, prefix); err != nil {
+				log.Printf("movie %d image cleanup: %s", movieID, err)
+			}
+			return errors.Wrap(err, "blob put")
+		}
+		return nil
+	}
+
+	if err := put(prefix+"original"+ext, data); err != nil {
+		return nil, err
+	}
+
+	for _, width := range artwork.Widths[kind] {
+		thumb, err := artwork.Encode(artwork.Resize(img, width), contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		name := artwork.SizeName(width)
+		if err := put(prefix+name+ext, thumb); err != nil {
+			return nil, err
+		}
+		image.Sizes[name] = baseURL + prefix + name + ext
+	}
+	return image, nil
+}
+
+// dropImage removes the blobs of a replaced or deleted image.
+func dropImage(ctx context.Context, store blob.Store, movieID int, image *domain.Image) {
+	if image == nil || image.Prefix == "" {
+		return
+	}
+	if err := store.DeletePrefix(ctx, image.Prefix); err != nil {
+		log.Printf("movie %d image cleanup: %s", movieID, err)
+	}
+}
+
+// imagePurger removes the images of purged movies.
+type imagePurger struct {
+	store blob.Store
+}
+
+// NewImagePurger returns the hook which removes the images of purged movies.
+func NewImagePurger(store blob.Store) Hook {
+	return &imagePurger{store: store}
+}
+
+func (p *imagePurger) MovieSaved(context.Context, domain.Movie) error { return nil }
+
+func (p *imagePurger) MoviePurged(ctx context.Context, id int) error {
+	return p.store.DeletePrefix(ctx, "movies/"+strconv.Itoa(id)+"/")
+}
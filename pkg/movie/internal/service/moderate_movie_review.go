@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type ModerateMovieReview struct {
+	Cluster *gocb.Cluster
+	Repo    *gocb.Bucket
+}
+
+type ModerateMovieReviewRequest struct {
+	ID       int    `path:"id"`
+	ReviewID string `path:"reviewId"`
+	Hidden   bool   `json:"hidden" description:"hide the review text, the rating keeps counting"`
+}
+
+type ModerateMovieReviewResponse struct {
+	Review domain.Review `json:"review"`
+}
+
+func NewModerateMovieReview(cluster *gocb.Cluster, repo *gocb.Bucket) *ModerateMovieReview {
+	return &ModerateMovieReview{Cluster: cluster, Repo: repo}
+}
+
+func (m *ModerateMovieReview) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Moderate Movie Review",
+		Description: "Hide or show a review and resolve its flags",
+		Method:      http.MethodPut,
+		Path:        "/v1/movies/{id}/reviews/{reviewId}/moderation",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     ModerateMovieReviewRequest{},
+		Response:    ModerateMovieReviewResponse{},
+	}
+}
+
+func (m *ModerateMovieReview) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		req := ModerateMovieReviewRequest{ID: id, ReviewID: chi.URLParam(r, "reviewId")}
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "moderation body unmarshal").Error())
+		}
+
+		return m.handle(ctx, req)
+	}
+}
+
+// handle applies the decision of the moderator. The flags are resolved by it, so they are cleared.
+func (m *ModerateMovieReview) handle(_ context.Context, r ModerateMovieReviewRequest) (*ModerateMovieReviewResponse, error) {
+	review, err := saveReview(m.Cluster, m.Repo, r.ID, r.ReviewID, func(prev *domain.Review) (*domain.Review, error) {
+		if prev == nil || prev.MovieID != r.ID {
+			return nil, errors.NewNotFoundError("review not found", "no review "+r.ReviewID)
+		}
+
+		next := *prev
+		next.Hidden, next.Flags = r.Hidden, []domain.Flag{}
+		return &next, nil
+	})
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &ModerateMovieReviewResponse{Review: *review}, nil
+}
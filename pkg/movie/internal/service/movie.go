@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+// getMovie returns the movie with its cas value. Soft deleted movies are reported as not found.
+func getMovie(repo *gocb.Bucket, id int) (*domain.Movie, uint64, error) {
+	doc, err := repo.Scope("movie").Collection("movie").Get(strconv.Itoa(id), &gocb.GetOptions{
+		Timeout: time.Second * 3,
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "couchbase query")
+	}
+
+	var movie domain.Movie
+	if err := doc.Content(&movie); err != nil {
+		return nil, 0, errors.Wrap(err, "row parse")
+	}
+
+	if movie.IsDeleted() {
+		return nil, 0, errors.Wrap(errors.ErrNotFound, "movie is deleted")
+	}
+
+	return &movie, uint64(doc.Cas()), nil
+}
+
+// maxLookupInSpecs is the number of paths couchbase accepts in a single sub-document lookup.
+const maxLookupInSpecs = 16
+
+// getMovieFields reads only the fields of the movie, with the cas value of the movie. Fields missing
+// from the document are left empty. Soft deleted movies are reported as not found. Selections too
+// large for a single lookup read the whole document.
+func getMovieFields(repo *gocb.Bucket, id int, fields []string) (*domain.Movie, uint64, error) {
+	if len(fields) == 0 || len(fields)+1 > maxLookupInSpecs {
+		return getMovie(repo, id)
+	}
+
+	specs := []gocb.LookupInSpec{gocb.ExistsSpec("DeletedAt", nil)}
+	for _, f := range fields {
+		specs = append(specs, gocb.GetSpec(f, nil))
+	}
+
+	res, err := repo.Scope("movie").Collection("movie").LookupIn(strconv.Itoa(id), specs, &gocb.LookupInOptions{
+		Timeout: 3 * time.Second,
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "couchbase query")
+	}
+
+	if res.Exists(0) {
+		return nil, 0, errors.Wrap(errors.ErrNotFound, "movie is deleted")
+	}
+
+	doc := make(map[string]json.RawMessage, len(fields))
+	for i, f := range fields {
+		if !res.Exists(uint(i + 1)) {
+			continue
+		}
+
+		var v json.RawMessage
+		if err := res.ContentAt(uint(i+1), &v); err != nil {
+			return nil, 0, errors.Wrap(err, "row parse")
+		}
+		doc[f] = v
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "movie marshal")
+	}
+
+	var movie domain.Movie
+	if err := json.Unmarshal(b, &movie); err != nil {
+		return nil, 0, errors.Wrap(err, "row parse")
+	}
+	return &movie, uint64(res.Cas()), nil
+}
+
+// checkIfMatch validates the If-Match header of a write against the current cas value of the movie.
+func checkIfMatch(ifMatch string, required bool, cas uint64) error {
+	if ifMatch == "" {
+		if required {
+			return errors.NewPreconditionRequiredError("If-Match header is required", "missing If-Match header")
+		}
+		return nil
+	}
+
+	if !etag.MatchStrong(ifMatch, cas) {
+		return errors.NewPreconditionFailedError("movie has been modified", "If-Match mismatch")
+	}
+	return nil
+}
+
+// countQuery runs a `SELECT RAW COUNT(*)` query and returns the count.
+func countQuery(repo *gocb.Bucket, query string, params ...interface{}) (int, error) {
+	res, err := repo.Scope("movie").Query(query, &gocb.QueryOptions{PositionalParameters: params})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+
+	var count int
+	if err := res.One(&count); err != nil {
+		return 0, errors.Wrap(err, "row parse")
+	}
+	return count, nil
+}
+
+// People answers which of the person ids credited by movies do not belong to a person, and
+// names the people for responses.
+type People interface {
+	Missing(ctx context.Context, ids []int) ([]int, error)
+	Names(ctx context.Context, ids []int) (map[int]string, error)
+}
+
+// checkPeople rejects credits of unknown people. Without a directory every person is accepted.
+func checkPeople(ctx context.Context, people People, ids ...int) error {
+	if people == nil || len(ids) == 0 {
+		return nil
+	}
+
+	missing, err := people.Missing(ctx, ids)
+	if err != nil {
+		return errors.Translate(errors.Wrap(err, "person lookup"))
+	}
+
+	if len(missing) > 0 {
+		s := make([]string, len(missing))
+		for i, id := range missing {
+			s[i] = strconv.Itoa(id)
+		}
+		return errors.NewBadRequestError("unknown person ids: "+strings.Join(s, ", "), "unknown people")
+	}
+	return nil
+}
+
+// creditedPeople returns the distinct person ids of the cast and crew, in ascending order.
+func creditedPeople(movie domain.Movie) []int {
+	seen := map[int]bool{}
+	for _, c := range movie.Cast {
+		seen[c.PersonID] = true
+	}
+	for _, c := range movie.Crew {
+		seen[c.PersonID] = true
+	}
+
+	ids := make([]int, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// addedPeople returns the person ids credited in the movie but not in the stored one, so that
+// credits stored before people were checked stay writable.
+func addedPeople(movie, stored domain.Movie) []int {
+	credited := map[int]bool{}
+	for _, id := range creditedPeople(stored) {
+		credited[id] = true
+	}
+
+	var ids []int
+	for _, id := range creditedPeople(movie) {
+		if !credited[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Terms normalises the genres and keywords of the movies against the taxonomy and hands out
+// the rewrites queued by term changes.
+type Terms interface {
+	Normalize(ctx context.Context, kind string, values, kept []string) ([]string, error)
+	Rewrites(ctx context.Context, rewrite func(ctx context.Context, kind string, from []string, into string) (int, error)) error
+}
+
+// termFields are the movie fields holding the terms of each taxonomy kind.
+var termFields = map[string]string{"genre": "Genres", "keyword": "Keywords"}
+
+// normalizeTerms spells the genres and keywords of the movie like their terms. Unknown and
+// deprecated genres the stored movie already had are kept. Without a taxonomy the movie is left as is.
+func normalizeTerms(ctx context.Context, terms Terms, movie *domain.Movie, stored domain.Movie) error {
+	if terms == nil {
+		return nil
+	}
+
+	var err error
+	if movie.Genres, err = terms.Normalize(ctx, "genre", movie.Genres, stored.Genres); err != nil {
+		return errors.Translate(errors.Wrap(err, "genre normalization"))
+	}
+	if movie.Keywords, err = terms.Normalize(ctx, "keyword", movie.Keywords, stored.Keywords); err != nil {
+		return errors.Translate(errors.Wrap(err, "keyword normalization"))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/repository"
+)
+
+// MovieFacets holds the most frequent values of the requested facets with their movie counts.
+type MovieFacets struct {
+	Genres   []repository.FacetCount `json:"genres,omitempty"`
+	Language []repository.FacetCount `json:"language,omitempty"`
+	Country  []repository.FacetCount `json:"country,omitempty"`
+	Company  []repository.FacetCount `json:"company,omitempty"`
+	Decade   []repository.FacetCount `json:"decade,omitempty"`
+}
+
+func (f *MovieFacets) counts(facet repository.Facet) *[]repository.FacetCount {
+	switch facet {
+	case repository.FacetGenres:
+		return &f.Genres
+	case repository.FacetLanguage:
+		return &f.Language
+	case repository.FacetCountry:
+		return &f.Country
+	case repository.FacetCompany:
+		return &f.Company
+	}
+	return &f.Decade
+}
+
+// parseFacets parses a comma separated list of facets like "Genres,Decade". Repeated facets are
+// counted once, each facet has a single goroutine filling its field.
+func parseFacets(s string) ([]repository.Facet, error) {
+	var facets []repository.Facet
+	seen := map[repository.Facet]bool{}
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+
+		facet, ok := findFacet(name)
+		if !ok {
+			return nil, errors.NewBadRequestError("facet must be one of Genres, Language, Country, Company, Decade", "facet "+name)
+		}
+		if seen[facet] {
+			continue
+		}
+		seen[facet] = true
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+func findFacet(name string) (repository.Facet, bool) {
+	for _, f := range repository.Facets {
+		if string(f) == name {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// countFacets runs the facet aggregations of the query concurrently, each one filling its own field.
+func countFacets(ctx context.Context, repo repository.Movies, q repository.Query, facets []repository.Facet) (*MovieFacets, error) {
+	var (
+		res  MovieFacets
+		wg   sync.WaitGroup
+		errs = make([]error, len(facets))
+	)
+
+	for i, f := range facets {
+		wg.Add(1)
+		go func(i int, f repository.Facet) {
+			defer wg.Done()
+
+			counts, err := repo.Facet(ctx, q, f)
+			if err != nil {
+				errs[i] = errors.Wrap(err, "movie facet "+string(f))
+				return
+			}
+
+			*res.counts(f) = counts
+		}(i, f)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &res, nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/country"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/repository"
+)
+
+// MovieFilter holds the filter parameters shared by the movie listing and search routes.
+type MovieFilter struct {
+	Genre          []string  `query:"genre" description:"movies having any of the genres"`
+	Language       []string  `query:"language" description:"movies in any of the languages"`
+	Country        []string  `query:"country" description:"movies produced in any of the countries"`
+	Company        []string  `query:"company" description:"movies produced by any of the companies"`
+	CastPersonID   []int     `query:"castPersonId" description:"movies casting any of the people"`
+	ReleasedIn     []string  `query:"releasedIn" description:"movies released in any of the ISO 3166-1 alpha-2 countries, the release dates bound those releases"`
+	ReleaseType    []string  `query:"releaseType" description:"movies having any of the release types: theatrical, digital, physical, the release dates bound those releases"`
+	ReleasedAfter  time.Time `query:"releasedAfter" description:"movies released on or after the date, e.g. 2001-12-19"`
+	ReleasedBefore time.Time `query:"releasedBefore" description:"movies released before the date"`
+	RuntimeMin     int       `query:"runtimeMin" description:"minimum runtime in minutes"`
+	RuntimeMax     int       `query:"runtimeMax" description:"maximum runtime in minutes"`
+}
+
+// Parse reads the filter parameters. Repeated parameters match any of their values.
+func (r *MovieFilter) Parse(query url.Values) error {
+	r.Genre, r.Language = query["genre"], query["language"]
+	r.Country, r.Company = query["country"], query["company"]
+
+	for _, v := range query["castPersonId"] {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.NewBadRequestError("castPersonId must be integer", errors.Wrap(err, "castPersonId conversion").Error())
+		}
+		r.CastPersonID = append(r.CastPersonID, id)
+	}
+
+	for _, v := range query["releasedIn"] {
+		code := strings.ToUpper(v)
+		if !country.Valid(code) {
+			return errors.NewBadRequestError("releasedIn must be an ISO 3166-1 alpha-2 code like DE", "releasedIn "+v)
+		}
+		r.ReleasedIn = append(r.ReleasedIn, code)
+	}
+
+	for _, v := range query["releaseType"] {
+		if !containsString(domain.ReleaseTypes, v) {
+			return errors.NewBadRequestError("releaseType must be theatrical, digital or physical", "releaseType "+v)
+		}
+		r.ReleaseType = append(r.ReleaseType, v)
+	}
+
+	for name, t := range map[string]*time.Time{"releasedAfter": &r.ReleasedAfter, "releasedBefore": &r.ReleasedBefore} {
+		if v := query.Get(name); v != "" {
+			d, err := time.Parse("2006-01-02", v)
+			if err != nil {
+				return errors.NewBadRequestError(name+" must be a date like 2001-12-19", errors.Wrap(err, name+" conversion").Error())
+			}
+			*t = d
+		}
+	}
+
+	for name, n := range map[string]*int{"runtimeMin": &r.RuntimeMin, "runtimeMax": &r.RuntimeMax} {
+		if v := query.Get(name); v != "" {
+			minutes, err := strconv.Atoi(v)
+			if err != nil || minutes < 0 {
+				return errors.NewBadRequestError(name+" must be a non negative integer", name+" conversion")
+			}
+			*n = minutes
+		}
+	}
+
+	return nil
+}
+
+// Expr returns the AST of the filter parameters.
+func (r MovieFilter) Expr() repository.Expr {
+	f := repository.And{
+		anyOf("Genres", "", repository.Contains, r.Genre),
+		anyOf("Language", "", repository.Eq, r.Language),
+		anyOf("Country", "", repository.Contains, r.Country),
+		anyOf("Company", "", repository.Contains, r.Company),
+		anyOf("Cast", "PersonID", repository.Contains, r.CastPersonID),
+	}
+
+	if len(r.ReleasedIn) > 0 || len(r.ReleaseType) > 0 {
+		f = append(f, r.releases())
+	} else {
+		for _, c := range r.dates("ReleaseDate") {
+			f = append(f, c)
+		}
+	}
+	if r.RuntimeMin > 0 {
+		f = append(f, repository.Condition{Field: "Runtime", Op: repository.Gte, Value: r.RuntimeMin})
+	}
+	if r.RuntimeMax > 0 {
+		f = append(f, repository.Condition{Field: "Runtime", Op: repository.Lte, Value: r.RuntimeMax})
+	}
+	return f
+}
+
+// releases matches movies having a release in any of the countries and of any of the types,
+// within the release dates. Missing countries or types match every release.
+func (r MovieFilter) releases() repository.Expr {
+	countries, types := r.ReleasedIn, r.ReleaseType
+	if len(countries) == 0 {
+		countries = []string{""}
+	}
+	if len(types) == 0 {
+		types = []string{""}
+	}
+
+	var or repository.Or
+	for _, c := range countries {
+		for _, t := range types {
+			conditions := r.dates("Date")
+			if c != "" {
+				conditions = append(conditions, repository.Condition{Field: "Country", Op: repository.Eq, Value: c})
+			}
+			if t != "" {
+				conditions = append(conditions, repository.Condition{Field: "Type", Op: repository.Eq, Value: t})
+			}
+			or = append(or, repository.Any{Field: "Releases", Conditions: conditions})
+		}
+	}
+	return or
+}
+
+// dates returns the conditions of the release dates on the field.
+func (r MovieFilter) dates(field string) []repository.Condition {
+	var conditions []repository.Condition
+	if !r.ReleasedAfter.IsZero() {
+		conditions = append(conditions, repository.Condition{Field: field, Op: repository.Gte, Value: r.ReleasedAfter})
+	}
+	if !r.ReleasedBefore.IsZero() {
+		conditions = append(conditions, repository.Condition{Field: field, Op: repository.Lt, Value: r.ReleasedBefore})
+	}
+	return conditions
+}
+
+func anyOf[T any](field, elem string, op repository.Op, values []T) repository.Expr {
+	or := make(repository.Or, 0, len(values))
+	for _, v := range values {
+		or = append(or, repository.Condition{Field: field, Elem: elem, Op: op, Value: v})
+	}
+	return or
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/jsonpatch"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+// maxMutateInSpecs is the number of paths couchbase accepts in a single sub-document mutation.
+const maxMutateInSpecs = 16
+
+type PatchMovie struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	People         People
+	Terms          Terms
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type PatchMovieRequest struct {
+	ID          int    `path:"id"`
+	IfMatch     string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason      string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	ContentType string
+	Author      string
+	Patch       json.RawMessage `json:"patch"`
+}
+
+// MediaTypes documents the body of both patch formats.
+func (PatchMovieRequest) MediaTypes() map[string]any {
+	return map[string]any{
+		jsonpatch.MergeMediaType: domain.Movie{},
+		jsonpatch.MediaType:      []jsonpatch.Operation{},
+	}
+}
+
+type PatchMovieResponse struct {
+	etag string
+}
+
+func NewPatchMovie(repo *gocb.Bucket, requireIfMatch bool, people People, terms Terms, hooks Hooks, revisions *Revisions) *PatchMovie {
+	return &PatchMovie{Repo: repo, RequireIfMatch: requireIfMatch, People: people, Terms: terms, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *PatchMovie) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Patch Movie",
+		Description: "Partially update movie by id with a JSON Merge Patch or a JSON Patch",
+		Method:      http.MethodPatch,
+		Path:        "/v1/movies/{id}",
+		Headers:     map[string]string{"ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     PatchMovieRequest{},
+		Response:    PatchMovieResponse{},
+	}
+}
+
+func (m *PatchMovie) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, PatchMovieRequest{
+			ID:          id,
+			IfMatch:     r.Header.Get("If-Match"),
+			Reason:      r.Header.Get("X-Change-Reason"),
+			ContentType: r.Header.Get("Content-Type"),
+			Author:      principal.Email,
+			Patch:       body,
+		})
+		if err != nil {
+			var e *errors.Error
+			if errors.As(err, &e) && e.StatusCode == http.StatusUnsupportedMediaType {
+				w.Header().Set("Accept-Patch", jsonpatch.MergeMediaType+", "+jsonpatch.MediaType)
+			}
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *PatchMovie) handle(ctx context.Context, r PatchMovieRequest) (*PatchMovieResponse, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.ContentType)
+	if mediaType != jsonpatch.MergeMediaType && mediaType != jsonpatch.MediaType {
+		return nil, errors.NewUnsupportedMediaTypeError(
+			"content type must be "+jsonpatch.MergeMediaType+" or "+jsonpatch.MediaType, "content type "+r.ContentType)
+	}
+
+	old, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	movie, err := patchMovie(*old, mediaType, r.Patch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPeople(ctx, m.People, addedPeople(movie, *old)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = normalizeTerms(ctx, m.Terms, &movie, *old)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := jsonpatch.Diff(old, movie)
+	if err != nil {
+		return nil, errors.NewInternalServerError(errors.Wrap(err, "movie diff").Error())
+	}
+
+	if len(ops) == 0 {
+		return &PatchMovieResponse{etag: etag.Format(cas)}, nil
+	}
+
+	specs, err := mutateInSpecs(ops, movie)
+	if err != nil {
+		return nil, errors.NewInternalServerError(errors.Wrap(err, "mutate in specs").Error())
+	}
+
+	cas, err = m.repo(ctx, r.ID, specs, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	m.Hooks.saved(ctx, movie)
+
+	m.Revisions.record(movie, domain.ActionUpdate, r.Author, r.Reason)
+
+	return &PatchMovieResponse{etag: etag.Format(cas)}, nil
+}
+
+// patchMovie applies the patch to the movie and validates the result. The id and the
+// soft delete fields cannot be patched.
+func patchMovie(movie domain.Movie, mediaType string, patch []byte) (domain.Movie, error) {
+	doc, err := json.Marshal(movie)
+	if err != nil {
+		return domain.Movie{}, errors.NewInternalServerError(errors.Wrap(err, "movie marshal").Error())
+	}
+
+	if mediaType == jsonpatch.MergeMediaType {
+		doc, err = jsonpatch.Merge(doc, patch)
+	} else {
+		var ops []jsonpatch.Operation
+		if err = json.Unmarshal(patch, &ops); err != nil {
+			return domain.Movie{}, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "patch unmarshal").Error())
+		}
+		doc, err = jsonpatch.Apply(doc, ops)
+	}
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return domain.Movie{}, errors.NewConflictError("movie does not pass the patch test", err.Error())
+	}
+	if err != nil {
+		return domain.Movie{}, errors.NewBadRequestError("patch cannot be applied: "+err.Error(), errors.Wrap(err, "patch").Error())
+	}
+
+	var patched domain.Movie
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&patched); err != nil {
+		return domain.Movie{}, errors.NewBadRequestError("patched movie is invalid: "+err.Error(), errors.Wrap(err, "patched movie unmarshal").Error())
+	}
+
+	if patched.ID != movie.ID {
+		return domain.Movie{}, errors.NewBadRequestError("movie id cannot be patched", "patched id mismatch")
+	}
+	if patched.DeletedAt != nil || patched.DeletedBy != "" {
+		return domain.Movie{}, errors.NewBadRequestError("movie deletion cannot be patched", "patched deletion fields")
+	}
+	if !reflect.DeepEqual(patched.Images, movie.Images) {
+		return domain.Movie{}, errors.NewBadRequestError("movie images cannot be patched, upload them instead", "patched images")
+	}
+
+	patched.SyncReleaseDate()
+
+	err = patched.Validate()
+	if err != nil {
+		return domain.Movie{}, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	return patched, nil
+}
+
+// mutateInSpecs turns the diff into sub-document mutations of the changed paths of the document.
+// Diffs touching more paths than a single mutation allows are written per top level field.
+func mutateInSpecs(ops []jsonpatch.Operation, doc any) ([]gocb.MutateInSpec, error) {
+	if len(ops) > maxMutateInSpecs {
+		return fieldSpecs(ops, doc)
+	}
+
+	specs := make([]gocb.MutateInSpec, 0, len(ops))
+	for _, op := range ops {
+		tokens, err := jsonpatch.ParsePointer(op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case op.Op == jsonpatch.OpRemove:
+			specs = append(specs, gocb.RemoveSpec(subdocPath(tokens), nil))
+		case op.Op == jsonpatch.OpAdd && tokens[len(tokens)-1] == "-":
+			specs = append(specs, gocb.ArrayAppendSpec(subdocPath(tokens[:len(tokens)-1]), op.Value, nil))
+		case op.Op == jsonpatch.OpAdd:
+			specs = append(specs, gocb.UpsertSpec(subdocPath(tokens), op.Value, nil))
+		default:
+			specs = append(specs, gocb.ReplaceSpec(subdocPath(tokens), op.Value, nil))
+		}
+	}
+	return specs, nil
+}
+
+// fieldSpecs upserts or removes every top level field the operations touch.
+func fieldSpecs(ops []jsonpatch.Operation, doc any) ([]gocb.MutateInSpec, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "document marshal")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, errors.Wrap(err, "document unmarshal")
+	}
+
+	var specs []gocb.MutateInSpec
+	seen := map[string]bool{}
+	for _, op := range ops {
+		tokens, err := jsonpatch.ParsePointer(op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		field := tokens[0]
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+
+		if value, ok := fields[field]; ok {
+			specs = append(specs, gocb.UpsertSpec(field, value, nil))
+		} else {
+			specs = append(specs, gocb.RemoveSpec(field, nil))
+		}
+	}
+	return specs, nil
+}
+
+// subdocPath converts JSON pointer tokens into a sub-document path like Cast[3].Name.
+func subdocPath(tokens []string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		if _, err := strconv.Atoi(t); err == nil && b.Len() > 0 {
+			b.WriteString("[" + t + "]")
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(t)
+	}
+	return b.String()
+}
+
+// repo writes the changed paths only, guarded by the cas value the patch was applied to.
+func (m *PatchMovie) repo(_ context.Context, id int, specs []gocb.MutateInSpec, cas uint64) (uint64, error) {
+	res, err := m.Repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(id), specs, &gocb.MutateInOptions{
+		Cas:     gocb.Cas(cas),
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+	return uint64(res.Cas()), nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/couchbase/gocb/v2"
+)
+
+type PurgeTrash struct {
+	Repo      *gocb.Bucket
+	Retention time.Duration
+	Hooks     Hooks
+}
+
+func NewPurgeTrash(repo *gocb.Bucket, retention time.Duration, hooks Hooks) *PurgeTrash {
+	return &PurgeTrash{Repo: repo, Retention: retention, Hooks: hooks}
+}
+
+func (m *PurgeTrash) Job() application.Job {
+	return application.Job{
+		Name:     "Purge Trash",
+		Interval: time.Hour,
+		Run:      m.handle,
+	}
+}
+
+// handle hard deletes the movies which stayed in trash longer than the retention period.
+func (m *PurgeTrash) handle(ctx context.Context) error {
+	ids, err := m.repo(ctx, time.Now().UTC().Add(-m.Retention))
+	if err != nil {
+		return errors.Wrap(err, "purge trash")
+	}
+
+	for _, id := range ids {
+		m.Hooks.purged(ctx, id)
+	}
+	return nil
+}
+
+func (m *PurgeTrash) repo(ctx context.Context, deletedBefore time.Time) ([]int, error) {
+	query := "DELETE FROM `movie`.movie.movie AS m WHERE m.DeletedAt IS VALUED AND STR_TO_MILLIS(m.DeletedAt) < $1 RETURNING RAW m.ID"
+
+	rows, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{deletedBefore.UnixMilli()},
+		Context:              ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	var ids []int
+
+	for rows.Next() {
+		var id int
+
+		err := rows.Row(&id)
+		if err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type RestoreMovie struct {
+	Repo      *gocb.Bucket
+	Hooks     Hooks
+	Revisions *Revisions
+}
+
+type RestoreMovieRequest struct {
+	ID     int    `path:"id"`
+	Reason string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author string
+}
+
+type RestoreMovieResponse struct{}
+
+func NewRestoreMovie(repo *gocb.Bucket, hooks Hooks, revisions *Revisions) *RestoreMovie {
+	return &RestoreMovie{Repo: repo, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *RestoreMovie) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Restore Movie",
+		Description: "Restore soft deleted movie from trash",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies/{id}/restore",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     RestoreMovieRequest{},
+		Response:    RestoreMovieResponse{},
+	}
+}
+
+func (m *RestoreMovie) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, RestoreMovieRequest{ID: id, Reason: r.Header.Get("X-Change-Reason"), Author: principal.Email})
+	}
+}
+
+func (m *RestoreMovie) handle(ctx context.Context, r RestoreMovieRequest) (*RestoreMovieResponse, error) {
+	err := m.repo(ctx, r.ID)
+	if errors.Is(err, gocb.ErrPathNotFound) {
+		return nil, errors.NewNotFoundError("movie is not in trash", errors.Wrap(err, "couchbase query").Error())
+	}
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	movie, _, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	m.Hooks.saved(ctx, *movie)
+
+	m.Revisions.record(*movie, domain.ActionRestore, r.Author, r.Reason)
+
+	return &RestoreMovieResponse{}, nil
+}
+
+func (m *RestoreMovie) repo(_ context.Context, id int) error {
+	_, err := m.Repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(id), []gocb.MutateInSpec{
+		gocb.RemoveSpec("DeletedAt", nil),
+		gocb.RemoveSpec("DeletedBy", nil),
+	}, &gocb.MutateInOptions{Timeout: 5 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
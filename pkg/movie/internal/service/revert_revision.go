@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type RevertRevision struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	People         People
+	Terms          Terms
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type RevertRevisionRequest struct {
+	ID       int    `path:"id"`
+	Revision int    `path:"rev"`
+	IfMatch  string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason   string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author   string
+}
+
+type RevertRevisionResponse struct {
+	etag string
+}
+
+func NewRevertRevision(repo *gocb.Bucket, requireIfMatch bool, people People, terms Terms, hooks Hooks, revisions *Revisions) *RevertRevision {
+	return &RevertRevision{Repo: repo, RequireIfMatch: requireIfMatch, People: people, Terms: terms, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *RevertRevision) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Revert Revision",
+		Description: "Revert movie to the snapshot of the revision",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies/{id}/revisions/{rev}/revert",
+		Headers:     map[string]string{"ETag": "entity tag of the reverted movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     RevertRevisionRequest{},
+		Response:    RevertRevisionResponse{},
+	}
+}
+
+func (m *RevertRevision) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		rev, err := strconv.Atoi(chi.URLParam(r, "rev"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("rev must be integer", errors.Wrap(err, "rev conversion").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, RevertRevisionRequest{
+			ID:       id,
+			Revision: rev,
+			IfMatch:  r.Header.Get("If-Match"),
+			Reason:   r.Header.Get("X-Change-Reason"),
+			Author:   principal.Email,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *RevertRevision) handle(ctx context.Context, r RevertRevisionRequest) (*RevertRevisionResponse, error) {
+	rev, err := getRevision(m.Repo, r.ID, r.Revision)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	current, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	movie := *rev.Movie
+	movie.ID = r.ID
+	movie.DeletedAt, movie.DeletedBy = nil, ""
+	movie.SyncReleaseDate()
+
+	err = movie.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = checkPeople(ctx, m.People, addedPeople(movie, *current)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = normalizeTerms(ctx, m.Terms, &movie, *current)
+	if err != nil {
+		return nil, err
+	}
+
+	// The blobs of older images may be gone, the current images are kept.
+	movie.Images = current.Images
+
+	cas, err = m.repo(ctx, movie, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	if r.Reason == "" {
+		r.Reason = fmt.Sprintf("revert to revision %d", r.Revision)
+	}
+
+	m.Hooks.saved(ctx, movie)
+
+	m.Revisions.record(movie, domain.ActionRevert, r.Author, r.Reason)
+
+	return &RevertRevisionResponse{etag: etag.Format(cas)}, nil
+}
+
+func (m *RevertRevision) repo(_ context.Context, movie domain.Movie, cas uint64) (uint64, error) {
+	res, err := m.Repo.Scope("movie").Collection("movie").Replace(strconv.Itoa(movie.ID), movie, &gocb.ReplaceOptions{
+		Cas:     gocb.Cas(cas),
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+	return uint64(res.Cas()), nil
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+const (
+	// priorCount is the number of ratings the mean of all ratings weighs like in the weighted score.
+	priorCount = 10
+
+	// defaultPriorMean is the prior before any movie is rated, the middle of the rating scale.
+	defaultPriorMean = 5.5
+
+	// hideFlagCount is the number of flags which hide a review until a moderator looks at it.
+	hideFlagCount = 3
+)
+
+// reviewID derives the id of the review of the author for the movie, so that the review
+// document itself enforces one review per user and movie without exposing the author.
+func reviewID(movieID int, author string) string {
+	sum := sha256.Sum256([]byte(strconv.Itoa(movieID) + "::" + strings.ToLower(author)))
+	return hex.EncodeToString(sum[:10])
+}
+
+// saveReview passes the stored review, nil when there is none, to mutate and stores its result,
+// removing the review when it is nil. The ratings of the movie are moved by the difference in
+// the same transaction, so that the score never drifts from the reviews. It returns the stored review.
+func saveReview(cluster *gocb.Cluster, repo *gocb.Bucket, movieID int, id string, mutate func(prev *domain.Review) (*domain.Review, error)) (*domain.Review, error) {
+	reviews := repo.Scope("movie").Collection("review")
+	ratings := repo.Scope("movie").Collection("rating")
+
+	var (
+		next    *domain.Review
+		failure error
+	)
+	_, err := cluster.Transactions().Run(func(tc *gocb.TransactionAttemptContext) error {
+		failure = nil
+
+		var prev *domain.Review
+		doc, err := tc.Get(reviews, id)
+		if err != nil && !errors.Is(err, gocb.ErrDocumentNotFound) {
+			return err
+		}
+		if err == nil {
+			prev = &domain.Review{}
+			if err := doc.Content(prev); err != nil {
+				return errors.Wrap(err, "row parse")
+			}
+		}
+
+		if next, failure = mutate(prev); failure != nil {
+			return failure
+		}
+
+		var count, sum int
+		switch {
+		case prev == nil && next != nil:
+			_, err = tc.Insert(reviews, id, next)
+		case next != nil:
+			_, err = tc.Replace(doc, next)
+		case prev != nil:
+			err = tc.Remove(doc)
+		}
+		if err != nil {
+			return err
+		}
+
+		if prev != nil {
+			count, sum = count-1, sum-prev.Rating
+		}
+		if next != nil {
+			count, sum = count+1, sum+next.Rating
+		}
+		if count == 0 && sum == 0 {
+			return nil
+		}
+
+		return moveRatings(tc, ratings, movieID, count, sum)
+	}, &gocb.TransactionOptions{Timeout: 10 * time.Second})
+	if failure != nil {
+		return nil, failure
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase transaction")
+	}
+	return next, nil
+}
+
+func moveRatings(tc *gocb.TransactionAttemptContext, ratings *gocb.Collection, movieID, count, sum int) error {
+	key := strconv.Itoa(movieID)
+
+	doc, err := tc.Get(ratings, key)
+	if errors.Is(err, gocb.ErrDocumentNotFound) {
+		_, err = tc.Insert(ratings, key, domain.Ratings{MovieID: movieID, Count: count, Sum: sum})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	var r domain.Ratings
+	if err := doc.Content(&r); err != nil {
+		return errors.Wrap(err, "row parse")
+	}
+
+	r.Count, r.Sum = r.Count+count, r.Sum+sum
+	_, err = tc.Replace(doc, r)
+	return err
+}
+
+// getScore returns the score of the movie, the prior alone when it is not rated yet.
+func getScore(repo *gocb.Bucket, prior *RatingPrior, movieID int) (domain.Score, error) {
+	var r domain.Ratings
+
+	doc, err := repo.Scope("movie").Collection("rating").Get(strconv.Itoa(movieID), &gocb.GetOptions{Timeout: 3 * time.Second})
+	if err != nil && !errors.Is(err, gocb.ErrDocumentNotFound) {
+		return domain.Score{}, errors.Wrap(err, "couchbase query")
+	}
+	if err == nil {
+		if err := doc.Content(&r); err != nil {
+			return domain.Score{}, errors.Wrap(err, "row parse")
+		}
+	}
+
+	return r.Score(prior.Mean(), priorCount), nil
+}
+
+// RatingPrior is the mean of all ratings the weighted scores are pulled towards. It changes
+// slowly, so it is refreshed periodically instead of on every rating.
+type RatingPrior struct {
+	Repo *gocb.Bucket
+
+	mu   sync.RWMutex
+	mean float64
+}
+
+func NewRatingPrior(repo *gocb.Bucket) *RatingPrior {
+	return &RatingPrior{Repo: repo, mean: defaultPriorMean}
+}
+
+func (p *RatingPrior) Job() application.Job {
+	return application.Job{
+		Name:     "Rating Prior",
+		Interval: 10 * time.Minute,
+		Run:      p.Refresh,
+	}
+}
+
+func (p *RatingPrior) Mean() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.mean
+}
+
+// Refresh recomputes the mean from the ratings of every movie.
+func (p *RatingPrior) Refresh(ctx context.Context) error {
+	res, err := p.Repo.Scope("movie").Query("SELECT IFNULL(SUM(r.Count), 0) AS Count, IFNULL(SUM(r.Sum), 0) AS Sum FROM `movie`.movie.rating AS r", &gocb.QueryOptions{
+		Context: ctx,
+	})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+
+	var total domain.Ratings
+	if err := res.One(&total); err != nil {
+		return errors.Wrap(err, "row parse")
+	}
+
+	mean := defaultPriorMean
+	if total.Count > 0 {
+		mean = float64(total.Sum) / float64(total.Count)
+	}
+
+	p.mu.Lock()
+	p.mean = mean
+	p.mu.Unlock()
+	return nil
+}
+
+// reviewPurger removes the reviews and the ratings of purged movies.
+type reviewPurger struct {
+	repo *gocb.Bucket
+}
+
+// NewReviewPurger returns the hook which removes the reviews of purged movies.
+func NewReviewPurger(repo *gocb.Bucket) Hook {
+	return &reviewPurger{repo: repo}
+}
+
+func (p *reviewPurger) MovieSaved(context.Context, domain.Movie) error { return nil }
+
+func (p *reviewPurger) MoviePurged(ctx context.Context, id int) error {
+	_, err := p.repo.Scope("movie").Query("DELETE FROM `movie`.movie.review AS r WHERE r.MovieID = $1", &gocb.QueryOptions{
+		PositionalParameters: []interface{}{id},
+		Context:              ctx,
+	})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+
+	_, err = p.repo.Scope("movie").Collection("rating").Remove(strconv.Itoa(id), &gocb.RemoveOptions{Timeout: 3 * time.Second})
+	if err != nil && !errors.Is(err, gocb.ErrDocumentNotFound) {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+// Revisions stores an immutable snapshot of every movie write. Snapshots which fail to store are
+// queued and stored by the retry job, and revisions of purged movies are removed.
+type Revisions struct {
+	repo    *gocb.Bucket
+	mu      sync.Mutex
+	pending []domain.Revision
+}
+
+func NewRevisions(repo *gocb.Bucket) *Revisions {
+	return &Revisions{repo: repo}
+}
+
+func (r *Revisions) Job() application.Job {
+	return application.Job{
+		Name:     "Retry Revisions",
+		Interval: time.Minute,
+		Run:      r.retry,
+	}
+}
+
+// record stores the snapshot of the movie after a write.
+func (r *Revisions) record(movie domain.Movie, action, author, reason string) {
+	r.store(domain.Revision{
+		MovieID:   movie.ID,
+		Action:    action,
+		Author:    author,
+		Reason:    reason,
+		CreatedAt: time.Now().UTC(),
+		Movie:     &movie,
+	})
+}
+
+// store inserts the revision or queues it for the retry job.
+func (r *Revisions) store(rev domain.Revision) {
+	if err := r.insert(&rev); err != nil {
+		log.Printf("movie %d revision queued: %s", rev.MovieID, err)
+
+		r.mu.Lock()
+		r.pending = append(r.pending, rev)
+		r.mu.Unlock()
+	}
+}
+
+// retry stores the queued revisions in the order they were queued.
+func (r *Revisions) retry(_ context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	var failed []domain.Revision
+	for i := range pending {
+		if err := r.insert(&pending[i]); err != nil {
+			failed = append(failed, pending[i])
+		}
+	}
+
+	if len(failed) > 0 {
+		r.mu.Lock()
+		r.pending = append(failed, r.pending...)
+		r.mu.Unlock()
+		return errors.New(fmt.Sprintf("%d movie revisions still pending", len(failed)))
+	}
+	return nil
+}
+
+// insert reads the snapshot when it is missing, allocates the revision number from a per movie
+// counter document unless it already has one, and stores the revision. The number is kept on the
+// revision, so that a retried revision keeps its place among the revisions of the movie.
+func (r *Revisions) insert(rev *domain.Revision) error {
+	if rev.Movie == nil {
+		movie, _, err := getMovie(r.repo, rev.MovieID)
+		if err != nil {
+			return errors.Wrap(err, "couchbase query")
+		}
+		rev.Movie = movie
+	}
+
+	if rev.Revision == 0 {
+		counter, err := r.repo.DefaultCollection().Binary().Increment(revisionCounterKey(rev.MovieID), &gocb.IncrementOptions{
+			Initial: 1,
+			Delta:   1,
+			Timeout: 3 * time.Second,
+		})
+		if err != nil {
+			return errors.Wrap(err, "revision counter")
+		}
+		rev.Revision = int(counter.Content())
+	}
+
+	_, err := r.repo.Scope("movie").Collection("revision").Insert(revisionKey(rev.MovieID, rev.Revision), rev, &gocb.InsertOptions{Timeout: 5 * time.Second})
+	if err != nil && !errors.Is(err, gocb.ErrDocumentExists) {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
+
+func (r *Revisions) MovieSaved(context.Context, domain.Movie) error { return nil }
+
+// MoviePurged removes the revisions, the queued ones included, and the revision counter of the movie.
+func (r *Revisions) MoviePurged(ctx context.Context, id int) error {
+	r.mu.Lock()
+	kept := r.pending[:0]
+	for _, rev := range r.pending {
+		if rev.MovieID != id {
+			kept = append(kept, rev)
+		}
+	}
+	r.pending = kept
+	r.mu.Unlock()
+
+	_, err := r.repo.Scope("movie").Query("DELETE FROM `movie`.movie.revision AS r WHERE r.MovieID = $1", &gocb.QueryOptions{
+		PositionalParameters: []interface{}{id},
+		Context:              ctx,
+	})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+
+	_, err = r.repo.DefaultCollection().Remove(revisionCounterKey(id), &gocb.RemoveOptions{Timeout: 3 * time.Second})
+	if err != nil && !errors.Is(err, gocb.ErrDocumentNotFound) {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
+
+func getRevision(repo *gocb.Bucket, movieID, revision int) (*domain.Revision, error) {
+	doc, err := repo.Scope("movie").Collection("revision").Get(revisionKey(movieID, revision), &gocb.GetOptions{
+		Timeout: 3 * time.Second,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	var rev domain.Revision
+	if err := doc.Content(&rev); err != nil {
+		return nil, errors.Wrap(err, "row parse")
+	}
+	return &rev, nil
+}
+
+// checkRevisionAccess lets only admins read the revisions of movies in the trash or purged,
+// like the trash itself.
+func checkRevisionAccess(repo *gocb.Bucket, id int, admin bool) error {
+	if admin {
+		return nil
+	}
+
+	_, _, err := getMovie(repo, id)
+	if e := errors.Translate(err); e != nil {
+		if e.StatusCode == http.StatusNotFound {
+			return errors.NewForbiddenError("forbidden", "revisions of a deleted movie")
+		}
+		return e
+	}
+	return nil
+}
+
+func revisionCounterKey(movieID int) string {
+	return fmt.Sprintf("movie::%d::revision", movieID)
+}
+
+func revisionKey(movieID, revision int) string {
+	return fmt.Sprintf("%d::%d", movieID, revision)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/termkey"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+// maxRewriteRetries bounds how often the rewrite of a movie is retried after a concurrent write.
+const maxRewriteRetries = 5
+
+type RewriteTerms struct {
+	Repo      *gocb.Bucket
+	Terms     Terms
+	Hooks     Hooks
+	Revisions *Revisions
+}
+
+func NewRewriteTerms(repo *gocb.Bucket, terms Terms, hooks Hooks, revisions *Revisions) *RewriteTerms {
+	return &RewriteTerms{Repo: repo, Terms: terms, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *RewriteTerms) Job() application.Job {
+	return application.Job{
+		Name:     "Rewrite Terms",
+		Interval: time.Minute,
+		Run:      m.handle,
+	}
+}
+
+// handle carries out the rewrites queued by renamed, merged and aliased terms.
+func (m *RewriteTerms) handle(ctx context.Context) error {
+	return m.Terms.Rewrites(ctx, m.rewrite)
+}
+
+// rewrite replaces the values in the movies using them, soft deleted movies included so that
+// they come back from trash with current terms. Every changed movie gets a revision.
+func (m *RewriteTerms) rewrite(ctx context.Context, kind string, from []string, into string) (int, error) {
+	field, ok := termFields[kind]
+	if !ok {
+		return 0, errors.New("unknown taxonomy kind " + kind)
+	}
+
+	ids, err := m.affected(ctx, field, from)
+	if err != nil {
+		return 0, errors.Wrap(err, "affected movies")
+	}
+
+	reason := kind + " " + strings.Join(from, ", ") + " rewritten into " + into
+
+	var n int
+	for _, id := range ids {
+		movie, err := m.repo(ctx, id, field, from, into)
+		if err != nil {
+			return n, errors.Wrap(err, "movie "+strconv.Itoa(id))
+		}
+		if movie == nil {
+			continue
+		}
+		n++
+
+		m.Hooks.saved(ctx, *movie)
+
+		m.Revisions.record(*movie, domain.ActionUpdate, "", reason)
+	}
+	return n, nil
+}
+
+func (m *RewriteTerms) affected(ctx context.Context, field string, from []string) ([]int, error) {
+	query := "SELECT RAW movie.ID FROM `movie`.movie.movie WHERE ANY v IN movie." + field + " SATISFIES REGEXP_REPLACE(LOWER(v), $2, \"\") IN $1 END"
+
+	keys := make([]string, len(from))
+	for i, f := range from {
+		keys[i] = termkey.Fold(f)
+	}
+
+	rows, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{keys, termkey.Pattern},
+		Context:              ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	var ids []int
+
+	for rows.Next() {
+		var id int
+		if err := rows.Row(&id); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+	return ids, nil
+}
+
+// repo rewrites the values of one movie and returns it, or nil when it holds none of them anymore.
+func (m *RewriteTerms) repo(_ context.Context, id int, field string, from []string, into string) (*domain.Movie, error) {
+	coll := m.Repo.Scope("movie").Collection("movie")
+
+	for attempt := 0; ; attempt++ {
+		doc, err := coll.Get(strconv.Itoa(id), &gocb.GetOptions{Timeout: 3 * time.Second})
+		if errors.Is(err, gocb.ErrDocumentNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "couchbase query")
+		}
+
+		var movie domain.Movie
+		if err := doc.Content(&movie); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		values := &movie.Genres
+		if field == "Keywords" {
+			values = &movie.Keywords
+		}
+
+		var changed bool
+		if *values, changed = replaceTerms(*values, from, into); !changed {
+			return nil, nil
+		}
+
+		_, err = coll.MutateIn(strconv.Itoa(id), []gocb.MutateInSpec{
+			gocb.ReplaceSpec(field, *values, nil),
+		}, &gocb.MutateInOptions{Cas: doc.Cas(), Timeout: 5 * time.Second})
+		if errors.Is(err, gocb.ErrCasMismatch) && attempt < maxRewriteRetries {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "couchbase query")
+		}
+		return &movie, nil
+	}
+}
+
+// replaceTerms replaces the values spelled like one of the from values with into, keeping the
+// position of the first one and dropping duplicates.
+func replaceTerms(values, from []string, into string) ([]string, bool) {
+	replace := map[string]bool{}
+	for _, f := range from {
+		replace[termkey.Fold(f)] = true
+	}
+
+	var (
+		out     = make([]string, 0, len(values))
+		seen    = map[string]bool{}
+		changed bool
+	)
+	for _, v := range values {
+		if replace[termkey.Fold(v)] {
+			v, changed = into, true
+		}
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out, changed
+}
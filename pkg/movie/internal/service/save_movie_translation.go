@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/locale"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type SaveMovieTranslation struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type SaveMovieTranslationRequest struct {
+	ID          int    `path:"id"`
+	Locale      string `path:"locale" description:"language tag of the translation, like pt-BR"`
+	IfMatch     string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason      string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author      string
+	Translation domain.Translation `json:"translation"`
+}
+
+type SaveMovieTranslationResponse struct {
+	Locale      string             `json:"locale"`
+	Translation domain.Translation `json:"translation"`
+
+	etag string
+}
+
+func NewSaveMovieTranslation(repo *gocb.Bucket, requireIfMatch bool, hooks Hooks, revisions *Revisions) *SaveMovieTranslation {
+	return &SaveMovieTranslation{Repo: repo, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *SaveMovieTranslation) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Save Movie Translation",
+		Description: "Create or replace the translation of the movie texts to the locale",
+		Method:      http.MethodPut,
+		Path:        "/v1/movies/{id}/translations/{locale}",
+		Headers:     map[string]string{"ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     SaveMovieTranslationRequest{},
+		Response:    SaveMovieTranslationResponse{},
+	}
+}
+
+func (m *SaveMovieTranslation) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		tag, err := parseLocale(chi.URLParam(r, "locale"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var translation domain.Translation
+
+		err = json.Unmarshal(body, &translation)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "translation body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, SaveMovieTranslationRequest{
+			ID:          id,
+			Locale:      tag,
+			IfMatch:     r.Header.Get("If-Match"),
+			Reason:      r.Header.Get("X-Change-Reason"),
+			Author:      principal.Email,
+			Translation: translation,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		w.Header().Set("Content-Language", res.Locale)
+		return res, nil
+	}
+}
+
+func (m *SaveMovieTranslation) handle(ctx context.Context, r SaveMovieTranslationRequest) (*SaveMovieTranslationResponse, error) {
+	err := r.Translation.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	movie, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	if original, _ := locale.Canonical(movie.Language); original == r.Locale {
+		return nil, errors.NewBadRequestError("locale is the original language of the movie, update the movie instead", "translation to original language "+r.Locale)
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	cas, err = m.repo(ctx, r.ID, r.Locale, r.Translation, cas)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	if movie.Translations == nil {
+		movie.Translations = map[string]domain.Translation{}
+	}
+	movie.Translations[r.Locale] = r.Translation
+
+	m.Hooks.saved(ctx, *movie)
+
+	if r.Reason == "" {
+		r.Reason = "translation " + r.Locale + " saved"
+	}
+
+	m.Revisions.record(*movie, domain.ActionUpdate, r.Author, r.Reason)
+
+	return &SaveMovieTranslationResponse{Locale: r.Locale, Translation: r.Translation, etag: etag.Format(cas)}, nil
+}
+
+func (m *SaveMovieTranslation) repo(_ context.Context, id int, tag string, translation domain.Translation, cas uint64) (uint64, error) {
+	res, err := m.Repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(id), []gocb.MutateInSpec{
+		gocb.UpsertSpec(translationPath(tag), translation, &gocb.UpsertSpecOptions{CreatePath: true}),
+	}, &gocb.MutateInOptions{Cas: gocb.Cas(cas), Timeout: 5 * time.Second})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+	return uint64(res.Cas()), nil
+}
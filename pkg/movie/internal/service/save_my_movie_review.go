@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type SaveMyMovieReview struct {
+	Cluster *gocb.Cluster
+	Repo    *gocb.Bucket
+}
+
+type ReviewInput struct {
+	Rating int    `json:"Rating" description:"rating between 1 and 10"`
+	Title  string `json:"Title"`
+	Body   string `json:"Body"`
+}
+
+type SaveMyMovieReviewRequest struct {
+	ID     int `path:"id"`
+	Author string
+	Review ReviewInput `json:"review"`
+}
+
+type SaveMyMovieReviewResponse struct {
+	Review domain.Review `json:"review"`
+
+	created bool
+}
+
+func NewSaveMyMovieReview(cluster *gocb.Cluster, repo *gocb.Bucket) *SaveMyMovieReview {
+	return &SaveMyMovieReview{Cluster: cluster, Repo: repo}
+}
+
+func (m *SaveMyMovieReview) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Save My Movie Review",
+		Description: "Rate the movie and review it, replacing the earlier rating and review of the caller",
+		Method:      http.MethodPut,
+		Path:        "/v1/movies/{id}/reviews/mine",
+		Headers:     map[string]string{"Location": "path of the created review"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     SaveMyMovieReviewRequest{},
+		Response:    SaveMyMovieReviewResponse{},
+	}
+}
+
+func (m *SaveMyMovieReview) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var input ReviewInput
+
+		err = json.Unmarshal(body, &input)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "review body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, SaveMyMovieReviewRequest{ID: id, Author: principal.Email, Review: input})
+		if err != nil {
+			return nil, err
+		}
+
+		if res.created {
+			w.Header().Set("Location", "/v1/movies/"+idStr+"/reviews/mine")
+			w.WriteHeader(http.StatusCreated)
+		}
+		return res, nil
+	}
+}
+
+func (m *SaveMyMovieReview) handle(_ context.Context, r SaveMyMovieReviewRequest) (*SaveMyMovieReviewResponse, error) {
+	_, _, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	var created bool
+
+	review, err := saveReview(m.Cluster, m.Repo, r.ID, reviewID(r.ID, r.Author), func(prev *domain.Review) (*domain.Review, error) {
+		now := time.Now().UTC()
+
+		next := domain.Review{
+			ID:        reviewID(r.ID, r.Author),
+			MovieID:   r.ID,
+			Author:    r.Author,
+			Rating:    r.Review.Rating,
+			Title:     r.Review.Title,
+			Body:      r.Review.Body,
+			Flags:     []domain.Flag{},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		// Edits keep the moderation state, a hidden review stays hidden until a moderator looks at it.
+		created = prev == nil
+		if prev != nil {
+			next.CreatedAt, next.Hidden, next.Flags = prev.CreatedAt, prev.Hidden, prev.Flags
+		}
+
+		if err := next.Validate(); err != nil {
+			return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+		}
+		return &next, nil
+	})
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &SaveMyMovieReviewResponse{Review: *review, created: created}, nil
+}
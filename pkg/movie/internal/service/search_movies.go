@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/repository"
+)
+
+const maxSearchLength = 200
+
+type SearchMovies struct {
+	Search repository.Searcher
+}
+
+type SearchMoviesRequest struct {
+	pagination.Request
+	MovieFilter
+	Q string `query:"q" required:"true" description:"search terms matched against title, stories, keywords and cast names"`
+}
+
+type SearchMoviesResponse struct {
+	TotalCount int                    `json:"totalCount"`
+	Hits       []repository.SearchHit `json:"hits"`
+	Pagination pagination.Model       `json:"pagination"`
+}
+
+func NewSearchMovies(search repository.Searcher) *SearchMovies {
+	return &SearchMovies{Search: search}
+}
+
+func (m *SearchMovies) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Search Movies",
+		Description: "Search movies by relevance, matches are highlighted with <mark>",
+		Method:      http.MethodGet,
+		Path:        "/v1/movies/search",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Handler:     m.endpoint(ctx),
+		Request:     SearchMoviesRequest{},
+		Response:    SearchMoviesResponse{},
+	}
+}
+
+func (m *SearchMovies) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		var req SearchMoviesRequest
+
+		if err := req.Request.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		if err := req.MovieFilter.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		req.Q = strings.TrimSpace(r.URL.Query().Get("q"))
+		if req.Q == "" || len(req.Q) > maxSearchLength {
+			return nil, errors.NewBadRequestError("q must be between 1 and 200 characters", "search text length")
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *SearchMovies) handle(ctx context.Context, r SearchMoviesRequest) (*SearchMoviesResponse, error) {
+	res, err := m.Search.Search(ctx, repository.SearchQuery{
+		Text:   r.Q,
+		Filter: r.Expr(),
+		Offset: r.Offset(),
+		Limit:  r.Size,
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "movie search"))
+	}
+
+	p := pagination.New(r.Request, res.TotalCount)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &SearchMoviesResponse{TotalCount: res.TotalCount, Hits: res.Hits, Pagination: p}, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/suggest"
+)
+
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 20
+)
+
+type Suggest struct {
+	Index *suggest.Index
+}
+
+type SuggestRequest struct {
+	Q     string `query:"q" required:"true" description:"text typed so far"`
+	Type  string `query:"type" description:"movie or person, both when absent"`
+	Limit int    `query:"limit" description:"number of suggestions, at most 20"`
+}
+
+type SuggestResponse struct {
+	Suggestions []suggest.Suggestion `json:"suggestions"`
+}
+
+func NewSuggest(index *suggest.Index) *Suggest {
+	return &Suggest{Index: index}
+}
+
+func (m *Suggest) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Suggest",
+		Description: "Suggest movie titles and people as typed, tolerating typos",
+		Method:      http.MethodGet,
+		Path:        "/v1/suggest",
+		Handler:     m.endpoint(ctx),
+		Request:     SuggestRequest{},
+		Response:    SuggestResponse{},
+	}
+}
+
+func (m *Suggest) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		query := r.URL.Query()
+		req := SuggestRequest{
+			Q:     strings.TrimSpace(query.Get("q")),
+			Type:  query.Get("type"),
+			Limit: defaultSuggestLimit,
+		}
+
+		if req.Q == "" || len(req.Q) > maxSearchLength {
+			return nil, errors.NewBadRequestError("q must be between 1 and 200 characters", "suggest text length")
+		}
+
+		if req.Type != "" && req.Type != suggest.Movie && req.Type != suggest.Person {
+			return nil, errors.NewBadRequestError("type must be movie or person", "suggest type "+req.Type)
+		}
+
+		if l := query.Get("limit"); l != "" {
+			limit, err := strconv.Atoi(l)
+			if err != nil || limit < 1 || limit > maxSuggestLimit {
+				return nil, errors.NewBadRequestError("limit must be an integer between 1 and 20", "limit conversion")
+			}
+			req.Limit = limit
+		}
+
+		return m.handle(ctx, req)
+	}
+}
+
+func (m *Suggest) handle(_ context.Context, r SuggestRequest) (*SuggestResponse, error) {
+	return &SuggestResponse{Suggestions: m.Index.Suggest(r.Q, r.Type, r.Limit)}, nil
+}
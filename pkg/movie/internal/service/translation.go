@@ -0,0 +1,69 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/locale"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+)
+
+// localize puts each movie in the first of the preferred locales it is translated to and
+// returns the Content-Language of the result, the locales used in order of first use.
+func localize(movies []domain.Movie, preferred []string) string {
+	var used []string
+	for i := range movies {
+		var tag string
+		movies[i], tag = movies[i].Localize(preferred)
+
+		if tag != "" && !containsString(used, tag) {
+			used = append(used, tag)
+		}
+	}
+	return strings.Join(used, ", ")
+}
+
+// writeLanguage sets the headers of a response localized by Accept-Language.
+func writeLanguage(w http.ResponseWriter, language string) {
+	w.Header().Add("Vary", "Accept-Language")
+	if language != "" {
+		w.Header().Set("Content-Language", language)
+	}
+}
+
+// localizedFields returns the fields to read for a projection when the texts are localized:
+// the translations are needed whenever a localized field is selected.
+func localizedFields(fields []string, preferred []string) []string {
+	if fields == nil || len(preferred) == 0 || containsString(fields, "Translations") {
+		return fields
+	}
+	for _, f := range domain.LocalizedMovieFields {
+		if containsString(fields, f) {
+			return append(append([]string{}, fields...), "Translations")
+		}
+	}
+	return fields
+}
+
+func parseLocale(s string) (string, error) {
+	tag, err := locale.Canonical(s)
+	if err != nil {
+		return "", errors.NewBadRequestError("locale must be a language tag like pt-BR", errors.Wrap(err, "locale").Error())
+	}
+	return tag, nil
+}
+
+// translationPath is the sub-document path of the translation, escaped since tags hold dashes.
+func translationPath(tag string) string {
+	return "Translations.`" + tag + "`"
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/3n0ugh/allotropes/framework/application"
 	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
 	"github.com/3n0ugh/allotropes/internal/middleware"
 	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
 	"github.com/couchbase/gocb/v2"
@@ -17,27 +18,37 @@ import (
 )
 
 type UpdateMovie struct {
-	Repo *gocb.Bucket
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	People         People
+	Terms          Terms
+	Hooks          Hooks
+	Revisions      *Revisions
 }
 
 type UpdateMovieRequest struct {
-	ID    int          `path:"id"`
-	Movie domain.Movie `json:"movie"`
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason  string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author  string
+	Movie   domain.Movie `json:"movie"`
 }
 
-type UpdateMovieResponse struct{}
+type UpdateMovieResponse struct {
+	etag string
+}
 
-func NewUpdateMovie(repo *gocb.Bucket) *UpdateMovie {
-	return &UpdateMovie{Repo: repo}
+func NewUpdateMovie(repo *gocb.Bucket, requireIfMatch bool, people People, terms Terms, hooks Hooks, revisions *Revisions) *UpdateMovie {
+	return &UpdateMovie{Repo: repo, RequireIfMatch: requireIfMatch, People: people, Terms: terms, Hooks: hooks, Revisions: revisions}
 }
 
 func (m *UpdateMovie) Route(ctx context.Context) application.Route {
 	return application.Route{
 		Name:        "Update Movie",
-		Description: "Update movie by id",
-		Method:      http.MethodGet,
+		Description: "Update movie by id, keeping its translations when Translations is absent",
+		Method:      http.MethodPut,
 		Path:        "/v1/movies/{id}",
-		Headers:     map[string]string{},
+		Headers:     map[string]string{"ETag": "entity tag of the updated movie"},
 		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
 		Handler:     m.endpoint(ctx),
 		Request:     UpdateMovieRequest{},
@@ -66,28 +77,81 @@ func (m *UpdateMovie) endpoint(ctx context.Context) application.HandlerFunc {
 			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "movie body unmarshal").Error())
 		}
 
-		return m.handle(ctx, UpdateMovieRequest{ID: id, Movie: movie})
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, UpdateMovieRequest{
+			ID:      id,
+			IfMatch: r.Header.Get("If-Match"),
+			Reason:  r.Header.Get("X-Change-Reason"),
+			Author:  principal.Email,
+			Movie:   movie,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
 	}
 }
 
 func (m *UpdateMovie) handle(ctx context.Context, r UpdateMovieRequest) (*UpdateMovieResponse, error) {
+	if r.Movie.ID != 0 && r.Movie.ID != r.ID {
+		return nil, errors.NewBadRequestError("movie id does not match the path id", "body id and path id mismatch")
+	}
+	r.Movie.ID = r.ID
+	r.Movie.DeletedAt, r.Movie.DeletedBy = nil, ""
+	r.Movie.SyncReleaseDate()
+
 	err := r.Movie.Validate()
 	if err != nil {
 		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
 	}
 
-	err = m.repo(ctx, r.ID, r.Movie)
+	current, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkPeople(ctx, m.People, addedPeople(r.Movie, *current)...)
 	if err != nil {
-		return nil, errors.NewInternalServerError(errors.Wrap(err, "couchbase query").Error())
+		return nil, err
 	}
 
-	return &UpdateMovieResponse{}, nil
+	err = normalizeTerms(ctx, m.Terms, &r.Movie, *current)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Movie.Images = current.Images
+	if r.Movie.Translations == nil {
+		r.Movie.Translations = current.Translations
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	cas, err = m.repo(ctx, r.Movie, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	m.Hooks.saved(ctx, r.Movie)
+
+	m.Revisions.record(r.Movie, domain.ActionUpdate, r.Author, r.Reason)
+
+	return &UpdateMovieResponse{etag: etag.Format(cas)}, nil
 }
 
-func (m *UpdateMovie) repo(ctx context.Context, id int, movie domain.Movie) error {
-	_, err := m.Repo.Scope("movie").Collection("movie").Upsert(strconv.Itoa(movie.ID), movie, &gocb.UpsertOptions{Timeout: 5 * time.Second})
+func (m *UpdateMovie) repo(_ context.Context, movie domain.Movie, cas uint64) (uint64, error) {
+	res, err := m.Repo.Scope("movie").Collection("movie").Replace(strconv.Itoa(movie.ID), movie, &gocb.ReplaceOptions{
+		Cas:     gocb.Cas(cas),
+		Timeout: 5 * time.Second,
+	})
 	if err != nil {
-		return errors.Wrap(err, "couchbase query")
+		return 0, errors.Wrap(err, "couchbase query")
 	}
-	return nil
+	return uint64(res.Cas()), nil
 }
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type UpdateMovieCast struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type UpdateMovieCastRequest struct {
+	ID       int    `path:"id"`
+	PersonID int    `path:"personId"`
+	IfMatch  string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason   string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author   string
+	Credit   CastCredit `json:"credit"`
+}
+
+type UpdateMovieCastResponse struct {
+	Cast domain.Cast `json:"cast"`
+
+	etag string
+}
+
+func NewUpdateMovieCast(repo *gocb.Bucket, requireIfMatch bool, hooks Hooks, revisions *Revisions) *UpdateMovieCast {
+	return &UpdateMovieCast{Repo: repo, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *UpdateMovieCast) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Update Movie Cast",
+		Description: "Update cast credit of the person, keeping its billing unless CastOrder is given",
+		Method:      http.MethodPut,
+		Path:        "/v1/movies/{id}/cast/{personId}",
+		Headers:     map[string]string{"ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     UpdateMovieCastRequest{},
+		Response:    UpdateMovieCastResponse{},
+	}
+}
+
+func (m *UpdateMovieCast) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		personID, err := parsePersonID(chi.URLParam(r, "personId"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var credit CastCredit
+
+		err = json.Unmarshal(body, &credit)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "cast body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, UpdateMovieCastRequest{
+			ID:       id,
+			PersonID: personID,
+			IfMatch:  r.Header.Get("If-Match"),
+			Reason:   r.Header.Get("X-Change-Reason"),
+			Author:   principal.Email,
+			Credit:   credit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *UpdateMovieCast) handle(ctx context.Context, r UpdateMovieCastRequest) (*UpdateMovieCastResponse, error) {
+	if r.Credit.PersonID != 0 && r.Credit.PersonID != r.PersonID {
+		return nil, errors.NewBadRequestError("person id does not match the path person id", "body person id and path person id mismatch")
+	}
+	r.Credit.PersonID = r.PersonID
+	credit := r.Credit.Cast()
+
+	err := credit.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	if r.Credit.CastOrder != nil && *r.Credit.CastOrder < 0 {
+		return nil, errors.NewBadRequestError("cast order must not be negative", "negative cast order")
+	}
+
+	cas, err := mutateCredits(m.Repo, r.ID, "Cast", r.IfMatch, m.RequireIfMatch, func(cast []domain.Cast) ([]domain.Cast, error) {
+		cast = orderCast(cast)
+
+		i := castIndex(cast, r.PersonID)
+		if i < 0 {
+			return nil, errors.NewNotFoundError("person is not in the cast", "cast person "+strconv.Itoa(r.PersonID))
+		}
+
+		order := r.Credit.CastOrder
+		if order == nil {
+			order = &i
+		}
+
+		cast = placeCast(append(cast[:i:i], cast[i+1:]...), credit, order)
+		credit = cast[castIndex(cast, r.PersonID)]
+		return cast, nil
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	creditsSaved(ctx, m.Repo, m.Hooks, m.Revisions, r.ID, r.Author, r.Reason)
+
+	return &UpdateMovieCastResponse{Cast: credit, etag: etag.Format(cas)}, nil
+}
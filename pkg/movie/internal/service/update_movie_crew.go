@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type UpdateMovieCrew struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type UpdateMovieCrewRequest struct {
+	ID       int    `path:"id"`
+	PersonID int    `path:"personId"`
+	Job      string `query:"job" description:"job of the credit, required when the person holds several jobs"`
+	IfMatch  string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason   string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author   string
+	Credit   domain.Crew `json:"credit"`
+}
+
+type UpdateMovieCrewResponse struct {
+	Crew domain.Crew `json:"crew"`
+
+	etag string
+}
+
+func NewUpdateMovieCrew(repo *gocb.Bucket, requireIfMatch bool, hooks Hooks, revisions *Revisions) *UpdateMovieCrew {
+	return &UpdateMovieCrew{Repo: repo, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *UpdateMovieCrew) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Update Movie Crew",
+		Description: "Update crew credit of the person",
+		Method:      http.MethodPut,
+		Path:        "/v1/movies/{id}/crew/{personId}",
+		Headers:     map[string]string{"ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     UpdateMovieCrewRequest{},
+		Response:    UpdateMovieCrewResponse{},
+	}
+}
+
+func (m *UpdateMovieCrew) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		personID, err := parsePersonID(chi.URLParam(r, "personId"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var credit domain.Crew
+
+		err = json.Unmarshal(body, &credit)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "crew body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, UpdateMovieCrewRequest{
+			ID:       id,
+			PersonID: personID,
+			Job:      r.URL.Query().Get("job"),
+			IfMatch:  r.Header.Get("If-Match"),
+			Reason:   r.Header.Get("X-Change-Reason"),
+			Author:   principal.Email,
+			Credit:   credit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *UpdateMovieCrew) handle(ctx context.Context, r UpdateMovieCrewRequest) (*UpdateMovieCrewResponse, error) {
+	if r.Credit.PersonID != 0 && r.Credit.PersonID != r.PersonID {
+		return nil, errors.NewBadRequestError("person id does not match the path person id", "body person id and path person id mismatch")
+	}
+	r.Credit.PersonID = r.PersonID
+
+	cas, err := mutateCredits(m.Repo, r.ID, "Crew", r.IfMatch, m.RequireIfMatch, func(crew []domain.Crew) ([]domain.Crew, error) {
+		i, err := findCrew(crew, r.PersonID, r.Job)
+		if err != nil {
+			return nil, err
+		}
+
+		credit := r.Credit
+		if credit.Job == "" {
+			credit.Job = crew[i].Job
+		}
+
+		if err := credit.Validate(); err != nil {
+			return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+		}
+
+		if j := crewIndex(crew, credit.PersonID, credit.Job); j >= 0 && j != i {
+			return nil, errors.NewConflictError("person already holds the job in the crew", "duplicate crew person "+strconv.Itoa(credit.PersonID)+" "+credit.Job)
+		}
+
+		updated := append([]domain.Crew{}, crew...)
+		updated[i] = credit
+		r.Credit = credit
+		return updated, nil
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	creditsSaved(ctx, m.Repo, m.Hooks, m.Revisions, r.ID, r.Author, r.Reason)
+
+	return &UpdateMovieCrewResponse{Crew: r.Credit, etag: etag.Format(cas)}, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/blob"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/artwork"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+// multipartOverhead is the room left for the multipart framing around the image.
+const multipartOverhead = 64 << 10
+
+type UploadMovieImage struct {
+	Repo           *gocb.Bucket
+	Store          blob.Store
+	BaseURL        string
+	RequireIfMatch bool
+	Hooks          Hooks
+	Revisions      *Revisions
+}
+
+type UploadMovieImageRequest struct {
+	ID      int    `path:"id"`
+	Kind    string `path:"kind" description:"poster or backdrop"`
+	IfMatch string `header:"If-Match" description:"entity tag the movie must still have"`
+	Reason  string `header:"X-Change-Reason" description:"reason recorded in the movie revision"`
+	Author  string
+	File    []byte `json:"file"`
+}
+
+// ImageForm is the multipart form of an image upload.
+type ImageForm struct {
+	File []byte `json:"file" description:"JPEG or PNG image of at most 10 MiB"`
+}
+
+// MediaTypes documents the multipart body.
+func (UploadMovieImageRequest) MediaTypes() map[string]any {
+	return map[string]any{"multipart/form-data": ImageForm{}}
+}
+
+type UploadMovieImageResponse struct {
+	Image domain.Image `json:"image"`
+
+	etag string
+}
+
+func NewUploadMovieImage(repo *gocb.Bucket, store blob.Store, baseURL string, requireIfMatch bool, hooks Hooks, revisions *Revisions) *UploadMovieImage {
+	return &UploadMovieImage{Repo: repo, Store: store, BaseURL: baseURL, RequireIfMatch: requireIfMatch, Hooks: hooks, Revisions: revisions}
+}
+
+func (m *UploadMovieImage) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Upload Movie Image",
+		Description: "Upload the poster or backdrop of the movie as the file part of a multipart form, replacing the current one",
+		Method:      http.MethodPost,
+		Path:        "/v1/movies/{id}/images/{kind}",
+		Headers:     map[string]string{"Location": "URL of the uploaded image", "ETag": "entity tag of the updated movie"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     UploadMovieImageRequest{},
+		Response:    UploadMovieImageResponse{},
+	}
+}
+
+func (m *UploadMovieImage) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		kind, err := parseImageKind(chi.URLParam(r, "kind"))
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := readImagePart(w, r)
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, UploadMovieImageRequest{
+			ID:      id,
+			Kind:    kind,
+			IfMatch: r.Header.Get("If-Match"),
+			Reason:  r.Header.Get("X-Change-Reason"),
+			Author:  principal.Email,
+			File:    file,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", res.Image.URL)
+		w.Header().Set("ETag", res.etag)
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+// readImagePart reads the file part of the multipart body, rejecting images over the size limit
+// without reading further.
+func readImagePart(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, artwork.MaxBytes+multipartOverhead)
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.NewUnsupportedMediaTypeError("body must be multipart/form-data", errors.Wrap(err, "multipart").Error())
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, errors.NewBadRequestError("file part is required", "multipart without file")
+		}
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "multipart part").Error())
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, artwork.MaxBytes+1))
+		part.Close()
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, errors.NewPayloadTooLargeError("image must be at most 10 MiB", err.Error())
+			}
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "read file").Error())
+		}
+		if len(data) > artwork.MaxBytes {
+			return nil, errors.NewPayloadTooLargeError("image must be at most 10 MiB", "image size "+strconv.Itoa(len(data)))
+		}
+		return data, nil
+	}
+}
+
+func (m *UploadMovieImage) handle(ctx context.Context, r UploadMovieImageRequest) (*UploadMovieImageResponse, error) {
+	movie, cas, err := getMovie(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := storeImage(ctx, m.Store, m.BaseURL, r.ID, r.Kind, r.File)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	cas, err = m.repo(ctx, r.ID, r.Kind, image, cas)
+	if err != nil {
+		dropImage(ctx, m.Store, r.ID, image)
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	slot := &movie.Images.Poster
+	if r.Kind == artwork.Backdrop {
+		slot = &movie.Images.Backdrop
+	}
+	dropImage(ctx, m.Store, r.ID, *slot)
+	*slot = image
+
+	m.Hooks.saved(ctx, *movie)
+
+	if r.Reason == "" {
+		r.Reason = r.Kind + " uploaded"
+	}
+
+	m.Revisions.record(*movie, domain.ActionUpdate, r.Author, r.Reason)
+
+	return &UploadMovieImageResponse{Image: *image, etag: etag.Format(cas)}, nil
+}
+
+func (m *UploadMovieImage) repo(_ context.Context, id int, kind string, image *domain.Image, cas uint64) (uint64, error) {
+	res, err := m.Repo.Scope("movie").Collection("movie").MutateIn(strconv.Itoa(id), []gocb.MutateInSpec{
+		gocb.UpsertSpec(imageField(kind), image, &gocb.UpsertSpecOptions{CreatePath: true}),
+	}, &gocb.MutateInOptions{Cas: gocb.Cas(cas), Timeout: 5 * time.Second})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+	return uint64(res.Cas()), nil
+}
@@ -0,0 +1,512 @@
+package similar
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/repository"
+)
+
+// Feature fields, the movie fields two movies are compared by.
+const (
+	Genre           = "genre"
+	Keyword         = "keyword"
+	Cast            = "cast"
+	Director        = "director"
+	Writer          = "writer"
+	Composer        = "composer"
+	Cinematographer = "cinematographer"
+	Producer        = "producer"
+	Company         = "company"
+)
+
+const (
+	// size is the number of neighbours kept per movie.
+	size = 20
+
+	// topBilled is the number of cast members compared, supporting roles say little about a movie.
+	topBilled = 10
+
+	// maxPostings bounds the movies a feature nominates as candidates. Features most movies share
+	// are too weak to make a neighbour on their own, they still count in the score of candidates.
+	maxPostings = 2000
+
+	// maxReasons is the number of shared features explaining a neighbour.
+	maxReasons = 3
+)
+
+// fieldWeights weigh the features by field before their inverse document frequency.
+var fieldWeights = map[string]float64{
+	Genre:           1,
+	Keyword:         1.2,
+	Cast:            1.5,
+	Director:        2.5,
+	Writer:          1.5,
+	Composer:        1,
+	Cinematographer: 1,
+	Producer:        0.5,
+	Company:         0.7,
+}
+
+// crewFields are the crew jobs compared and their feature fields.
+var crewFields = map[string]string{
+	"Director":                Director,
+	"Screenplay":              Writer,
+	"Writer":                  Writer,
+	"Original Music Composer": Composer,
+	"Director of Photography": Cinematographer,
+	"Producer":                Producer,
+}
+
+// Reason is a feature two similar movies share. Value is the term, or the name of the person
+// for people, which is empty when the movies do not carry it.
+type Reason struct {
+	Field    string  `json:"field"`
+	Value    string  `json:"value"`
+	PersonID int     `json:"personId,omitempty"`
+	Weight   float64 `json:"weight"`
+}
+
+// Explain phrases the reason for the response.
+func (r Reason) Explain() string {
+	value := r.Value
+	if value == "" && r.PersonID != 0 {
+		value = "#" + strconv.Itoa(r.PersonID)
+	}
+
+	switch r.Field {
+	case Cast:
+		return "both star " + value
+	case Genre, Keyword, Company:
+		return "both have " + r.Field + " " + value
+	}
+	return "same " + r.Field + " " + value
+}
+
+type Neighbour struct {
+	ID      int      `json:"id"`
+	Title   string   `json:"title"`
+	Score   float64  `json:"score"`
+	Reasons []Reason `json:"reasons"`
+}
+
+type feature struct {
+	field string
+	value string
+}
+
+// corpus holds the features of the indexed movies, which the neighbours are computed from.
+type corpus struct {
+	features map[int][]feature
+	postings map[feature]map[int]struct{}
+	labels   map[feature]string
+}
+
+// clone copies the corpus, so that neighbours can be computed from it while the index changes.
+// The feature lists are shared, they are replaced rather than changed.
+func (c corpus) clone() corpus {
+	clone := corpus{
+		features: make(map[int][]feature, len(c.features)),
+		postings: make(map[feature]map[int]struct{}, len(c.postings)),
+		labels:   make(map[feature]string, len(c.labels)),
+	}
+	for id, fs := range c.features {
+		clone.features[id] = fs
+	}
+	for f, ids := range c.postings {
+		posting := make(map[int]struct{}, len(ids))
+		for id := range ids {
+			posting[id] = struct{}{}
+		}
+		clone.postings[f] = posting
+	}
+	for f, label := range c.labels {
+		clone.labels[f] = label
+	}
+	return clone
+}
+
+// Index keeps the most similar movies of every movie. Movies are compared by the weighted Jaccard
+// similarity of their features, each weighted by its field and its inverse document frequency, so
+// that rare shared features count more than common ones. The neighbours are rebuilt periodically,
+// as the frequencies drift, and updated incrementally as a movie write hook in between.
+type Index struct {
+	mu sync.RWMutex
+	corpus
+	titles     map[int]string
+	neighbours map[int][]Neighbour
+	// listed holds for every movie the movies whose neighbours it is part of.
+	listed map[int]map[int]struct{}
+
+	rebuilding bool
+	dirty      map[int]struct{}
+}
+
+func NewIndex() *Index {
+	return &Index{
+		corpus: corpus{
+			features: map[int][]feature{},
+			postings: map[feature]map[int]struct{}{},
+			labels:   map[feature]string{},
+		},
+		titles:     map[int]string{},
+		neighbours: map[int][]Neighbour{},
+		listed:     map[int]map[int]struct{}{},
+		dirty:      map[int]struct{}{},
+	}
+}
+
+// Load indexes every movie of the repository and computes their neighbours.
+func (x *Index) Load(ctx context.Context, movies repository.Movies) error {
+	err := repository.Each(ctx, movies, func(movie domain.Movie) error {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+
+		x.remove(movie.ID)
+		if !movie.IsDeleted() {
+			x.add(movie)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return x.Rebuild(ctx)
+}
+
+// Rebuild recomputes the neighbours of every movie with the current frequencies. They are computed
+// from a copy of the corpus without holding the lock, so that reads and writes go on meanwhile.
+// Movies written before the result is swapped in are updated after it.
+func (x *Index) Rebuild(ctx context.Context) error {
+	x.mu.Lock()
+	x.rebuilding = true
+	snapshot := x.corpus.clone()
+	x.mu.Unlock()
+
+	neighbours, err := snapshot.computeAll(ctx)
+	if err != nil {
+		x.mu.Lock()
+		x.rebuilding, x.dirty = false, map[int]struct{}{}
+		x.mu.Unlock()
+		return err
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	dirty := x.dirty
+	x.rebuilding, x.dirty = false, map[int]struct{}{}
+
+	x.neighbours, x.listed = map[int][]Neighbour{}, map[int]map[int]struct{}{}
+	for id, ns := range neighbours {
+		if _, ok := x.features[id]; !ok {
+			continue
+		}
+		for _, n := range ns {
+			if _, ok := x.features[n.ID]; ok {
+				x.neighbours[id] = append(x.neighbours[id], n)
+				x.list(n.ID, id)
+			}
+		}
+	}
+
+	for id := range dirty {
+		x.unlink(id)
+		if _, ok := x.features[id]; ok {
+			x.refresh(id)
+		}
+	}
+	return nil
+}
+
+func (c corpus) computeAll(ctx context.Context) (map[int][]Neighbour, error) {
+	neighbours := make(map[int][]Neighbour, len(c.features))
+	for id := range c.features {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		neighbours[id] = c.compute(id)
+	}
+	return neighbours, nil
+}
+
+// MovieSaved reindexes the movie and updates its neighbours and the neighbours of the movies
+// similar to it. Soft deleted movies are removed from the index.
+func (x *Index) MovieSaved(_ context.Context, movie domain.Movie) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(movie.ID)
+	if movie.IsDeleted() {
+		return nil
+	}
+
+	x.add(movie)
+	x.refresh(movie.ID)
+	return nil
+}
+
+func (x *Index) MoviePurged(_ context.Context, id int) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(id)
+	return nil
+}
+
+// Similar returns at most limit neighbours of the movie, most similar first. It reports false
+// for movies which are not indexed.
+func (x *Index) Similar(id, limit int) ([]Neighbour, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if _, ok := x.features[id]; !ok {
+		return nil, false
+	}
+
+	ns := x.neighbours[id]
+	if len(ns) > limit {
+		ns = ns[:limit]
+	}
+
+	similar := make([]Neighbour, len(ns))
+	for i, n := range ns {
+		similar[i] = n
+		similar[i].Title = x.titles[n.ID]
+		similar[i].Reasons = append([]Reason{}, n.Reasons...)
+	}
+	return similar, true
+}
+
+func (x *Index) add(movie domain.Movie) {
+	seen := map[feature]bool{}
+	var features []feature
+
+	put := func(f feature, label string) {
+		if f.value == "" || seen[f] {
+			return
+		}
+		seen[f] = true
+		features = append(features, f)
+
+		if label != "" {
+			x.labels[f] = label
+		}
+		if x.postings[f] == nil {
+			x.postings[f] = map[int]struct{}{}
+		}
+		x.postings[f][movie.ID] = struct{}{}
+	}
+
+	for _, g := range movie.Genres {
+		put(feature{Genre, strings.ToLower(g)}, g)
+	}
+	for _, k := range movie.Keywords {
+		put(feature{Keyword, strings.ToLower(k)}, k)
+	}
+	for _, c := range movie.Company {
+		put(feature{Company, strings.ToLower(c)}, c)
+	}
+	for _, c := range movie.Cast {
+		if c.CastOrder < topBilled && c.PersonID > 0 {
+			put(feature{Cast, strconv.Itoa(c.PersonID)}, c.Name)
+		}
+	}
+	for _, c := range movie.Crew {
+		if field, ok := crewFields[c.Job]; ok && c.PersonID > 0 {
+			put(feature{field, strconv.Itoa(c.PersonID)}, "")
+		}
+	}
+
+	x.features[movie.ID] = features
+	x.titles[movie.ID] = movie.Title
+}
+
+// remove drops the movie with its features and takes it out of every neighbour list.
+func (x *Index) remove(id int) {
+	features, ok := x.features[id]
+	if !ok {
+		return
+	}
+
+	for _, f := range features {
+		delete(x.postings[f], id)
+		if len(x.postings[f]) == 0 {
+			delete(x.postings, f)
+			delete(x.labels, f)
+		}
+	}
+	delete(x.features, id)
+	delete(x.titles, id)
+
+	x.unlink(id)
+	if x.rebuilding {
+		x.dirty[id] = struct{}{}
+	}
+}
+
+// unlink drops the neighbours of the movie and takes it out of the neighbours of other movies.
+func (x *Index) unlink(id int) {
+	for other := range x.listed[id] {
+		x.neighbours[other] = without(x.neighbours[other], id)
+	}
+	delete(x.listed, id)
+
+	for _, n := range x.neighbours[id] {
+		delete(x.listed[n.ID], id)
+	}
+	delete(x.neighbours, id)
+}
+
+// refresh computes the neighbours of the movie and offers the movie to each of them, similarity
+// being symmetric.
+func (x *Index) refresh(id int) {
+	x.neighbours[id] = x.compute(id)
+
+	for _, n := range x.neighbours[id] {
+		x.list(n.ID, id)
+		x.offer(n.ID, Neighbour{ID: id, Score: n.Score, Reasons: n.Reasons})
+	}
+	if x.rebuilding {
+		x.dirty[id] = struct{}{}
+	}
+}
+
+// offer adds the neighbour to the neighbours of the movie if it is among the most similar.
+func (x *Index) offer(id int, n Neighbour) {
+	ns := without(x.neighbours[id], n.ID)
+	delete(x.listed[n.ID], id)
+
+	i := sort.Search(len(ns), func(i int) bool { return less(n, ns[i]) })
+	if i >= size {
+		x.neighbours[id] = ns
+		return
+	}
+
+	ns = append(ns[:i], append([]Neighbour{n}, ns[i:]...)...)
+	if len(ns) > size {
+		delete(x.listed[ns[size].ID], id)
+		ns = ns[:size]
+	}
+	x.neighbours[id] = ns
+	x.list(n.ID, id)
+}
+
+// list records that the movie is part of the neighbours of other.
+func (x *Index) list(id, other int) {
+	if x.listed[id] == nil {
+		x.listed[id] = map[int]struct{}{}
+	}
+	x.listed[id][other] = struct{}{}
+}
+
+// compute scores the candidates sharing a feature with the movie and keeps the most similar.
+func (c corpus) compute(id int) []Neighbour {
+	features := c.features[id]
+
+	candidates := map[int]struct{}{}
+	for _, f := range features {
+		if len(c.postings[f]) > maxPostings {
+			continue
+		}
+		for other := range c.postings[f] {
+			if other != id {
+				candidates[other] = struct{}{}
+			}
+		}
+	}
+
+	weights := make(map[feature]float64, len(features))
+	var total float64
+	for _, f := range features {
+		weights[f] = c.weight(f)
+		total += weights[f]
+	}
+
+	neighbours := make([]Neighbour, 0, len(candidates))
+	for other := range candidates {
+		var shared []Reason
+		var intersection float64
+		for _, f := range features {
+			if _, ok := c.postings[f][other]; ok {
+				intersection += weights[f]
+				shared = append(shared, c.reason(f, weights[f]))
+			}
+		}
+
+		var otherTotal float64
+		for _, f := range c.features[other] {
+			otherTotal += c.weight(f)
+		}
+
+		union := total + otherTotal - intersection
+		if union <= 0 || intersection <= 0 {
+			continue
+		}
+
+		sort.SliceStable(shared, func(i, j int) bool { return shared[i].Weight > shared[j].Weight })
+		if len(shared) > maxReasons {
+			shared = shared[:maxReasons]
+		}
+
+		neighbours = append(neighbours, Neighbour{ID: other, Score: round(intersection / union), Reasons: shared})
+	}
+
+	sort.Slice(neighbours, func(i, j int) bool { return less(neighbours[i], neighbours[j]) })
+	if len(neighbours) > size {
+		neighbours = neighbours[:size]
+	}
+	return neighbours
+}
+
+// weight is the field weight scaled by the inverse document frequency of the feature.
+func (c corpus) weight(f feature) float64 {
+	df := len(c.postings[f])
+	if df == 0 {
+		return 0
+	}
+	return fieldWeights[f.field] * math.Log(1+float64(len(c.features))/float64(df))
+}
+
+func (c corpus) reason(f feature, weight float64) Reason {
+	r := Reason{Field: f.field, Value: c.labels[f], Weight: round(weight)}
+	if f.field == Cast || crewField(f.field) {
+		r.PersonID, _ = strconv.Atoi(f.value)
+	}
+	return r
+}
+
+func crewField(field string) bool {
+	for _, f := range crewFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func less(a, b Neighbour) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.ID < b.ID
+}
+
+func without(ns []Neighbour, id int) []Neighbour {
+	for i, n := range ns {
+		if n.ID == id {
+			return append(ns[:i:i], ns[i+1:]...)
+		}
+	}
+	return ns
+}
+
+func round(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
@@ -0,0 +1,125 @@
+package similar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIndex(t *testing.T) *Index {
+	x := NewIndex()
+	for _, m := range []domain.Movie{
+		{ID: 1, Title: "Heat", Genres: []string{"Crime", "Drama"}, Keywords: []string{"heist"},
+			Cast: []domain.Cast{{Name: "Al Pacino", PersonID: 10}}, Crew: []domain.Crew{{Job: "Director", PersonID: 20}}},
+		{ID: 2, Title: "Collateral", Genres: []string{"Crime", "Drama"},
+			Cast: []domain.Cast{{Name: "Tom Cruise", PersonID: 11}}, Crew: []domain.Crew{{Job: "Director", PersonID: 20}}},
+		{ID: 3, Title: "The Insider", Genres: []string{"Drama"},
+			Cast: []domain.Cast{{Name: "Al Pacino", PersonID: 10}}, Crew: []domain.Crew{{Job: "Director", PersonID: 20}}},
+		{ID: 4, Title: "Inside Man", Genres: []string{"Crime"}, Keywords: []string{"Heist"},
+			Crew: []domain.Crew{{Job: "Sound", PersonID: 20}}},
+		{ID: 5, Title: "Up", Genres: []string{"Animation"}},
+	} {
+		assert.NoError(t, x.MovieSaved(context.Background(), m))
+	}
+	return x
+}
+
+func ids(ns []Neighbour) []int {
+	ids := []int{}
+	for _, n := range ns {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestIndex_Similar(t *testing.T) {
+	x := newTestIndex(t)
+
+	similar, ok := x.Similar(1, 10)
+	assert.True(t, ok)
+	assert.Equal(t, []int{3, 2, 4}, ids(similar))
+	assert.Equal(t, "The Insider", similar[0].Title)
+	assert.Equal(t, Director, similar[0].Reasons[0].Field)
+	assert.Equal(t, 20, similar[0].Reasons[0].PersonID)
+
+	similar, ok = x.Similar(5, 10)
+	assert.True(t, ok)
+	assert.Empty(t, similar)
+
+	_, ok = x.Similar(6, 10)
+	assert.False(t, ok)
+}
+
+func TestIndex_Similar_ShouldLimit(t *testing.T) {
+	similar, _ := newTestIndex(t).Similar(1, 1)
+
+	assert.Equal(t, []int{3}, ids(similar))
+}
+
+func TestIndex_MovieSaved_ShouldUpdateNeighboursIncrementally(t *testing.T) {
+	x := newTestIndex(t)
+
+	err := x.MovieSaved(context.Background(), domain.Movie{ID: 5, Title: "Up", Genres: []string{"Animation"}, Keywords: []string{"heist"}})
+	assert.NoError(t, err)
+
+	similar, _ := x.Similar(4, 10)
+	assert.Contains(t, ids(similar), 5)
+
+	deletedAt := time.Now()
+	err = x.MovieSaved(context.Background(), domain.Movie{ID: 3, DeletedAt: &deletedAt})
+	assert.NoError(t, err)
+
+	similar, _ = x.Similar(1, 10)
+	assert.NotContains(t, ids(similar), 3)
+
+	assert.NoError(t, x.MoviePurged(context.Background(), 5))
+	similar, _ = x.Similar(4, 10)
+	assert.NotContains(t, ids(similar), 5)
+}
+
+func TestIndex_Rebuild(t *testing.T) {
+	x := newTestIndex(t)
+	before, _ := x.Similar(2, 10)
+
+	assert.NoError(t, x.Rebuild(context.Background()))
+
+	after, _ := x.Similar(2, 10)
+	assert.Equal(t, ids(before), ids(after))
+}
+
+func TestIndex_Rebuild_ShouldKeepWritesMadeMeanwhile(t *testing.T) {
+	x := newTestIndex(t)
+
+	done := make(chan error)
+	go func() { done <- x.Rebuild(context.Background()) }()
+
+	deletedAt := time.Now()
+	assert.NoError(t, x.MovieSaved(context.Background(), domain.Movie{ID: 3, DeletedAt: &deletedAt}))
+	assert.NoError(t, x.MovieSaved(context.Background(), domain.Movie{ID: 5, Title: "Up", Keywords: []string{"heist"}}))
+	assert.NoError(t, <-done)
+
+	similar, _ := x.Similar(1, 10)
+	assert.NotContains(t, ids(similar), 3)
+	assert.Contains(t, ids(similar), 5)
+}
+
+func TestReason_Explain(t *testing.T) {
+	testCases := map[string]struct {
+		Given    Reason
+		Expected string
+	}{
+		"should explain shared crew":        {Given: Reason{Field: Director, Value: "Michael Mann", PersonID: 20}, Expected: "same director Michael Mann"},
+		"should explain shared cast":        {Given: Reason{Field: Cast, Value: "Al Pacino", PersonID: 10}, Expected: "both star Al Pacino"},
+		"should explain shared term":        {Given: Reason{Field: Keyword, Value: "heist"}, Expected: "both have keyword heist"},
+		"should fall back to the person id": {Given: Reason{Field: Writer, PersonID: 7}, Expected: "same writer #7"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.Given.Explain())
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package suggest
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/repository"
+)
+
+// Suggestion types.
+const (
+	Movie  = "movie"
+	Person = "person"
+)
+
+// topBilled is the number of cast members whose credits make the popularity of a movie.
+const topBilled = 5
+
+// namesBatchSize bounds the people whose names are looked up at once while loading.
+const namesBatchSize = 100
+
+// People resolves the names of the people credited in the crew only, crew credits carry no name.
+type People interface {
+	Names(ctx context.Context, ids []int) (map[int]string, error)
+}
+
+type Suggestion struct {
+	Type  string  `json:"type"`
+	ID    int     `json:"id"`
+	Text  string  `json:"text"`
+	Score float64 `json:"score"`
+}
+
+type key struct {
+	typ string
+	id  int
+}
+
+type entry struct {
+	text  string
+	words []string
+}
+
+// Index suggests movie titles and people names matching a prefix as typed, tolerating typos.
+// Matches are weighted by popularity: the number of movies crediting a person, and for a movie
+// the credits of its top billed cast. It is kept current as a movie write hook.
+type Index struct {
+	people  People
+	mu      sync.RWMutex
+	words   *trie
+	entries map[key]entry
+	movies  map[int]domain.Movie
+	credits map[int]map[int]struct{}
+}
+
+func NewIndex(people People) *Index {
+	return &Index{
+		people:  people,
+		words:   newTrie(),
+		entries: map[key]entry{},
+		movies:  map[int]domain.Movie{},
+		credits: map[int]map[int]struct{}{},
+	}
+}
+
+// Load indexes every movie of the repository, then looks up the names of the crew in batches.
+func (x *Index) Load(ctx context.Context, movies repository.Movies) error {
+	err := repository.Each(ctx, movies, func(movie domain.Movie) error {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+
+		x.save(movie)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return x.name(ctx, x.unnamed())
+}
+
+// MovieSaved reindexes the movie and its people, soft deleted movies are removed from the index.
+func (x *Index) MovieSaved(ctx context.Context, movie domain.Movie) error {
+	x.mu.Lock()
+	x.save(movie)
+	x.mu.Unlock()
+
+	return x.name(ctx, x.unnamedCrew(movie.Crew))
+}
+
+func (x *Index) save(movie domain.Movie) {
+	x.remove(movie.ID)
+	if movie.IsDeleted() {
+		return
+	}
+
+	x.movies[movie.ID] = movie
+	x.set(key{Movie, movie.ID}, movie.Title)
+
+	for _, c := range movie.Cast {
+		x.credit(c.PersonID, movie.ID)
+		x.set(key{Person, c.PersonID}, c.Name)
+	}
+	for _, c := range movie.Crew {
+		x.credit(c.PersonID, movie.ID)
+	}
+}
+
+// unnamed returns the credited people without a name in the index.
+func (x *Index) unnamed() []int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var ids []int
+	for id := range x.credits {
+		if _, ok := x.entries[key{Person, id}]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// unnamedCrew returns the credited people of the crew without a name in the index.
+func (x *Index) unnamedCrew(crew []domain.Crew) []int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var ids []int
+	for _, c := range crew {
+		if _, ok := x.entries[key{Person, c.PersonID}]; !ok && x.credits[c.PersonID] != nil {
+			ids = append(ids, c.PersonID)
+		}
+	}
+	return ids
+}
+
+// name looks up the names of the people and indexes the ones still credited. People named in
+// a cast in the meantime keep the name of their cast credit.
+func (x *Index) name(ctx context.Context, ids []int) error {
+	if x.people == nil {
+		return nil
+	}
+
+	for start := 0; start < len(ids); start += namesBatchSize {
+		end := start + namesBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		names, err := x.people.Names(ctx, ids[start:end])
+		if err != nil {
+			return errors.Wrap(err, "people names")
+		}
+
+		x.mu.Lock()
+		for id, name := range names {
+			k := key{Person, id}
+			if _, ok := x.entries[k]; !ok && x.credits[id] != nil && name != "" {
+				x.set(k, name)
+			}
+		}
+		x.mu.Unlock()
+	}
+	return nil
+}
+
+// MoviePurged removes the movie, and the people left without credits, from the index.
+func (x *Index) MoviePurged(_ context.Context, id int) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(id)
+	return nil
+}
+
+func (x *Index) credit(personID, movieID int) {
+	if x.credits[personID] == nil {
+		x.credits[personID] = map[int]struct{}{}
+	}
+	x.credits[personID][movieID] = struct{}{}
+}
+
+func (x *Index) remove(id int) {
+	movie, ok := x.movies[id]
+	if !ok {
+		return
+	}
+
+	delete(x.movies, id)
+	x.unset(key{Movie, id})
+
+	for _, c := range movie.Cast {
+		x.uncredit(c.PersonID, id)
+	}
+	for _, c := range movie.Crew {
+		x.uncredit(c.PersonID, id)
+	}
+}
+
+func (x *Index) uncredit(personID, movieID int) {
+	delete(x.credits[personID], movieID)
+	if len(x.credits[personID]) == 0 {
+		delete(x.credits, personID)
+		x.unset(key{Person, personID})
+	}
+}
+
+func (x *Index) set(k key, text string) {
+	x.unset(k)
+
+	e := entry{text: text, words: words(text)}
+	for _, w := range e.words {
+		x.words.add(w, k)
+	}
+	x.entries[k] = e
+}
+
+func (x *Index) unset(k key) {
+	e, ok := x.entries[k]
+	if !ok {
+		return
+	}
+
+	for _, w := range e.words {
+		x.words.remove(w, k)
+	}
+	delete(x.entries, k)
+}
+
+// Suggest returns at most limit suggestions of the type, or of every type when typ is empty.
+// Every word of the query has to prefix a word of the text: exactly up to two characters,
+// within one edit up to five characters, and within two edits beyond.
+func (x *Index) Suggest(query, typ string, limit int) []Suggestion {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	terms := words(query)
+	if len(terms) == 0 {
+		return []Suggestion{}
+	}
+
+	var matched map[key]int
+	for _, term := range terms {
+		found := x.words.match(term, maxDistance(term))
+		if matched == nil {
+			matched = found
+			continue
+		}
+
+		for k, d := range matched {
+			if fd, ok := found[k]; ok {
+				matched[k] = d + fd
+			} else {
+				delete(matched, k)
+			}
+		}
+	}
+
+	prefix := strings.Join(terms, " ")
+	suggestions := []Suggestion{}
+	for k, dist := range matched {
+		if typ != "" && k.typ != typ {
+			continue
+		}
+
+		e := x.entries[k]
+		score := math.Log(2+x.popularity(k)) / float64(1+dist)
+		if strings.HasPrefix(strings.Join(e.words, " "), prefix) {
+			score *= 1.5
+		}
+		suggestions = append(suggestions, Suggestion{Type: k.typ, ID: k.id, Text: e.text, Score: score})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Text != b.Text {
+			return a.Text < b.Text
+		}
+		return a.ID < b.ID
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func (x *Index) popularity(k key) float64 {
+	if k.typ == Person {
+		return float64(len(x.credits[k.id]))
+	}
+
+	var credits int
+	for _, c := range x.movies[k.id].Cast {
+		if c.CastOrder < topBilled {
+			credits += len(x.credits[c.PersonID])
+		}
+	}
+	return float64(credits)
+}
+
+func maxDistance(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	}
+	return 2
+}
+
+// words splits the text into lower cased words.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
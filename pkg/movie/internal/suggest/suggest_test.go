@@ -0,0 +1,119 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/3n0ugh/allotropes/pkg/movie/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubPeople map[int]string
+
+func (p stubPeople) Names(_ context.Context, ids []int) (map[int]string, error) {
+	names := map[int]string{}
+	for _, id := range ids {
+		if name, ok := p[id]; ok {
+			names[id] = name
+		}
+	}
+	return names, nil
+}
+
+func newTestIndex(t *testing.T) *Index {
+	x := NewIndex(stubPeople{11: "Marlon Brando", 13: "Michael Mann"})
+	for _, m := range []domain.Movie{
+		{ID: 1, Title: "The Godfather", Cast: []domain.Cast{{Name: "Al Pacino", PersonID: 10}, {Name: "Marlon Brando", PersonID: 11, CastOrder: 1}}},
+		{ID: 2, Title: "The Godfather Part II", Cast: []domain.Cast{{Name: "Al Pacino", PersonID: 10}}},
+		{ID: 3, Title: "Gods and Monsters", Cast: []domain.Cast{{Name: "Ian McKellen", PersonID: 12}}},
+		{ID: 4, Title: "Heat", Cast: []domain.Cast{{Name: "Al Pacino", PersonID: 10}}, Crew: []domain.Crew{{PersonID: 11}}},
+	} {
+		assert.NoError(t, x.MovieSaved(context.Background(), m))
+	}
+	return x
+}
+
+func TestIndex_Suggest(t *testing.T) {
+	testCases := map[string]struct {
+		Query    string
+		Type     string
+		Expected []string
+	}{
+		"should rank exact prefix before typo": {
+			Query:    "godf",
+			Type:     Movie,
+			Expected: []string{"The Godfather", "The Godfather Part II", "Gods and Monsters"},
+		},
+		"should weight prefix of the whole text and popularity": {
+			Query:    "god",
+			Type:     Movie,
+			Expected: []string{"The Godfather", "Gods and Monsters", "The Godfather Part II"},
+		},
+		"should tolerate a typo": {
+			Query:    "gidf",
+			Type:     Movie,
+			Expected: []string{"The Godfather", "The Godfather Part II"},
+		},
+		"should tolerate two typos in long words": {
+			Query:    "godfaher part",
+			Type:     Movie,
+			Expected: []string{"The Godfather Part II"},
+		},
+		"should not tolerate typos in short words": {
+			Query:    "hx",
+			Expected: []string{},
+		},
+		"should rank people by credits": {
+			Query:    "a",
+			Type:     Person,
+			Expected: []string{"Al Pacino"},
+		},
+		"should match every type": {
+			Query:    "marlon",
+			Expected: []string{"Marlon Brando"},
+		},
+	}
+
+	x := newTestIndex(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual := x.Suggest(tc.Query, tc.Type, 10)
+
+			texts := []string{}
+			for _, s := range actual {
+				texts = append(texts, s.Text)
+			}
+			assert.Equal(t, tc.Expected, texts)
+		})
+	}
+}
+
+func TestIndex_MovieSaved_ShouldRemoveDeletedMovieAndUncreditedPeople(t *testing.T) {
+	x := newTestIndex(t)
+	now := time.Now()
+
+	assert.NoError(t, x.MovieSaved(context.Background(), domain.Movie{ID: 3, Title: "Gods and Monsters", DeletedAt: &now}))
+	assert.NoError(t, x.MoviePurged(context.Background(), 4))
+
+	assert.Empty(t, x.Suggest("mckellen", "", 10))
+	if actual := x.Suggest("pacino", "", 10); assert.Len(t, actual, 1) {
+		assert.Equal(t, key{Person, 10}, key{actual[0].Type, actual[0].ID})
+	}
+	assert.Equal(t, 2, len(x.credits[10]))
+	assert.Equal(t, 1, len(x.credits[11]))
+}
+
+func TestIndex_MovieSaved_ShouldNameCrewOnlyPeople(t *testing.T) {
+	x := newTestIndex(t)
+
+	assert.NoError(t, x.MovieSaved(context.Background(), domain.Movie{ID: 5, Title: "Collateral", Crew: []domain.Crew{{PersonID: 13}}}))
+
+	if actual := x.Suggest("mann", Person, 10); assert.Len(t, actual, 1) {
+		assert.Equal(t, Suggestion{Type: Person, ID: 13, Text: "Michael Mann", Score: actual[0].Score}, actual[0])
+	}
+
+	assert.NoError(t, x.MoviePurged(context.Background(), 5))
+	assert.Empty(t, x.Suggest("mann", Person, 10))
+}
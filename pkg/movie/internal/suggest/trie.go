@@ -0,0 +1,103 @@
+package suggest
+
+// trie holds the words of the suggestion texts, each word node keeping the entries having it.
+type trie struct {
+	children map[rune]*trie
+	entries  map[key]struct{}
+}
+
+func newTrie() *trie {
+	return &trie{children: map[rune]*trie{}}
+}
+
+func (t *trie) add(word string, k key) {
+	n := t
+	for _, r := range word {
+		child, ok := n.children[r]
+		if !ok {
+			child = newTrie()
+			n.children[r] = child
+		}
+		n = child
+	}
+
+	if n.entries == nil {
+		n.entries = map[key]struct{}{}
+	}
+	n.entries[k] = struct{}{}
+}
+
+func (t *trie) remove(word string, k key) {
+	n := t
+	for _, r := range word {
+		if n = n.children[r]; n == nil {
+			return
+		}
+	}
+	delete(n.entries, k)
+}
+
+// match returns the entries having a word which starts with a prefix within maxDist edits of
+// the query, with the smallest such distance. It walks the trie computing a Levenshtein row per
+// node and prunes the branches which cannot get back within maxDist.
+func (t *trie) match(query string, maxDist int) map[key]int {
+	q := []rune(query)
+	row := make([]int, len(q)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	found := map[key]int{}
+	for r, child := range t.children {
+		child.walk(q, r, row, maxDist, maxDist+1, found)
+	}
+	return found
+}
+
+// walk visits the node reached with r. best is the smallest distance between the query and
+// a prefix on the path, every word below matches with it once it is within maxDist.
+func (t *trie) walk(q []rune, r rune, prev []int, maxDist, best int, found map[key]int) {
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	lowest := row[0]
+
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if q[i-1] == r {
+			cost = 0
+		}
+		row[i] = min3(prev[i]+1, row[i-1]+1, prev[i-1]+cost)
+		if row[i] < lowest {
+			lowest = row[i]
+		}
+	}
+
+	if row[len(row)-1] < best {
+		best = row[len(row)-1]
+	}
+	if best > maxDist && lowest > maxDist {
+		return
+	}
+
+	if best <= maxDist {
+		for k := range t.entries {
+			if d, ok := found[k]; !ok || best < d {
+				found[k] = best
+			}
+		}
+	}
+
+	for r, child := range t.children {
+		child.walk(q, r, row, maxDist, best, found)
+	}
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,40 @@
+package person
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/config"
+	"github.com/3n0ugh/allotropes/internal/sequence"
+	"github.com/3n0ugh/allotropes/pkg/person/internal/service"
+	"github.com/couchbase/gocb/v2"
+)
+
+func InitController(ctx context.Context, c config.Config, db *gocb.Bucket, pq *sql.DB) application.Controller {
+	personIDs := sequence.New(c.Application.MovieIDSource, "person", db, pq)
+	if err := sequence.Seed(ctx, personIDs, db, "person", "person"); err != nil {
+		log.Printf("person id seed: %s", err)
+	}
+
+	addPersonSvc := service.NewAddPerson(db, personIDs)
+	getPeopleSvc := service.NewGetPeople(db)
+	getPersonByIDSvc := service.NewGetPersonByID(db)
+	getPersonCreditsSvc := service.NewGetPersonCredits(db)
+	updatePersonSvc := service.NewUpdatePerson(db, c.Application.RequireIfMatch)
+	deletePersonSvc := service.NewDeletePerson(db, c.Application.RequireIfMatch)
+
+	return application.Controller{
+		Name:        "Person",
+		Description: "Person related services",
+		Routes: []application.Route{
+			addPersonSvc.Route(ctx),
+			getPeopleSvc.Route(ctx),
+			getPersonByIDSvc.Route(ctx),
+			getPersonCreditsSvc.Route(ctx),
+			updatePersonSvc.Route(ctx),
+			deletePersonSvc.Route(ctx),
+		},
+	}
+}
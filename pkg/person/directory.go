@@ -0,0 +1,69 @@
+package person
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/couchbase/gocb/v2"
+)
+
+// Directory answers which people exist, for resources referencing people by id.
+type Directory struct {
+	repo *gocb.Bucket
+}
+
+func NewDirectory(repo *gocb.Bucket) *Directory {
+	return &Directory{repo: repo}
+}
+
+// Missing returns the ids which do not belong to a person, in the given order.
+func (d *Directory) Missing(ctx context.Context, ids []int) ([]int, error) {
+	names, err := d.Names(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []int
+	for _, id := range ids {
+		if _, ok := names[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// Names returns the names of the people found by id.
+func (d *Directory) Names(_ context.Context, ids []int) (map[int]string, error) {
+	names := map[int]string{}
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = strconv.Itoa(id)
+	}
+
+	rows, err := d.repo.Scope("person").Query("SELECT person.ID, person.Name FROM `person`.person.person USE KEYS $1", &gocb.QueryOptions{
+		PositionalParameters: []interface{}{keys},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	for rows.Next() {
+		var p struct {
+			ID   int    `json:"ID"`
+			Name string `json:"Name"`
+		}
+		if err := rows.Row(&p); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+		names[p.ID] = p.Name
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+	return names, nil
+}
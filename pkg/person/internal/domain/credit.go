@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Credits are the cast and crew credits of a person across the movies which are not deleted,
+// most recent release first.
+type Credits struct {
+	Cast []CastCredit `json:"cast"`
+	Crew []CrewCredit `json:"crew"`
+}
+
+type CastCredit struct {
+	MovieID     int       `json:"MovieID"`
+	Title       string    `json:"Title"`
+	ReleaseDate time.Time `json:"ReleaseDate"`
+	CastOrder   int       `json:"CastOrder"`
+}
+
+type CrewCredit struct {
+	MovieID     int       `json:"MovieID"`
+	Title       string    `json:"Title"`
+	ReleaseDate time.Time `json:"ReleaseDate"`
+	Department  string    `json:"Department"`
+	Job         string    `json:"Job"`
+}
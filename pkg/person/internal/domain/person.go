@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+type Person struct {
+	ID                 int        `json:"ID"`
+	Name               string     `json:"Name"`
+	Biography          string     `json:"Biography"`
+	BirthDate          *time.Time `json:"BirthDate,omitempty"`
+	KnownForDepartment string     `json:"KnownForDepartment"`
+}
+
+func (p Person) Validate() error {
+	if p.Name == "" {
+		return errors.New("person name is required")
+	}
+	if p.BirthDate != nil && p.BirthDate.After(time.Now()) {
+		return errors.New("person birth date must not be in the future")
+	}
+	return nil
+}
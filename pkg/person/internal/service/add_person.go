@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/internal/sequence"
+	"github.com/3n0ugh/allotropes/pkg/person/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+type AddPerson struct {
+	Repo *gocb.Bucket
+	IDs  sequence.Sequence
+}
+
+type AddPersonRequest struct {
+	Person domain.Person `json:"person"`
+}
+
+type AddPersonResponse struct {
+	ID int `json:"id"`
+}
+
+func NewAddPerson(repo *gocb.Bucket, ids sequence.Sequence) *AddPerson {
+	return &AddPerson{Repo: repo, IDs: ids}
+}
+
+func (m *AddPerson) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Add Person",
+		Description: "Add person",
+		Method:      http.MethodPost,
+		Path:        "/v1/people",
+		Headers:     map[string]string{"Location": "path of the created person"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     AddPersonRequest{},
+		Response:    AddPersonResponse{},
+	}
+}
+
+func (m *AddPerson) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var person domain.Person
+
+		err = json.Unmarshal(body, &person)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "person body unmarshal").Error())
+		}
+
+		res, err := m.handle(ctx, AddPersonRequest{Person: person})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", "/v1/people/"+strconv.Itoa(res.ID))
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+func (m *AddPerson) handle(ctx context.Context, r AddPersonRequest) (*AddPersonResponse, error) {
+	if r.Person.ID != 0 {
+		return nil, errors.NewBadRequestError("person id is assigned by the server", "client supplied person id")
+	}
+
+	err := r.Person.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	r.Person.ID, err = sequence.Insert(ctx, m.IDs, func(id int) error {
+		r.Person.ID = id
+		return m.repo(ctx, r.Person)
+	})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	return &AddPersonResponse{ID: r.Person.ID}, nil
+}
+
+func (m *AddPerson) repo(_ context.Context, person domain.Person) error {
+	_, err := m.Repo.Scope("person").Collection("person").Insert(strconv.Itoa(person.ID), person, &gocb.InsertOptions{Timeout: 5 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
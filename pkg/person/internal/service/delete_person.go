@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type DeletePerson struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+}
+
+type DeletePersonRequest struct {
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the person must still have"`
+}
+
+type DeletePersonResponse struct{}
+
+func NewDeletePerson(repo *gocb.Bucket, requireIfMatch bool) *DeletePerson {
+	return &DeletePerson{Repo: repo, RequireIfMatch: requireIfMatch}
+}
+
+func (m *DeletePerson) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Person",
+		Description: "Delete person which is not credited in any movie",
+		Method:      http.MethodDelete,
+		Path:        "/v1/people/{id}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeletePersonRequest{},
+		Response:    DeletePersonResponse{},
+	}
+}
+
+func (m *DeletePerson) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		return m.handle(ctx, DeletePersonRequest{ID: id, IfMatch: r.Header.Get("If-Match")})
+	}
+}
+
+// handle refuses to delete people still credited, soft deleted movies included, so that
+// restoring a movie never brings back dangling credits.
+func (m *DeletePerson) handle(ctx context.Context, r DeletePersonRequest) (*DeletePersonResponse, error) {
+	_, cas, err := getPerson(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	credited, err := m.credited(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	if credited {
+		return nil, errors.NewConflictError("person is credited in movies", "person "+strconv.Itoa(r.ID)+" is credited")
+	}
+
+	err = m.repo(ctx, r.ID, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	return &DeletePersonResponse{}, nil
+}
+
+func (m *DeletePerson) credited(_ context.Context, id int) (bool, error) {
+	query := "SELECT RAW COUNT(*) FROM `movie`.movie.movie " +
+		"WHERE ANY c IN movie.Cast SATISFIES c.PersonID = $1 END OR ANY c IN movie.Crew SATISFIES c.PersonID = $1 END"
+
+	res, err := m.Repo.Scope("movie").Query(query, &gocb.QueryOptions{PositionalParameters: []interface{}{id}})
+	if err != nil {
+		return false, errors.Wrap(err, "couchbase query")
+	}
+
+	var count int
+	if err := res.One(&count); err != nil {
+		return false, errors.Wrap(err, "row parse")
+	}
+	return count > 0, nil
+}
+
+func (m *DeletePerson) repo(_ context.Context, id int, cas uint64) error {
+	_, err := m.Repo.Scope("person").Collection("person").Remove(strconv.Itoa(id), &gocb.RemoveOptions{
+		Cas:     gocb.Cas(cas),
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
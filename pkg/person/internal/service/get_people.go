@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/person/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+type GetPeople struct {
+	Repo *gocb.Bucket
+}
+
+type GetPeopleRequest struct {
+	pagination.Request
+}
+
+type GetPeopleResponse struct {
+	TotalCount int              `json:"totalCount"`
+	People     []domain.Person  `json:"people"`
+	Pagination pagination.Model `json:"pagination"`
+}
+
+func NewGetPeople(repo *gocb.Bucket) *GetPeople {
+	return &GetPeople{Repo: repo}
+}
+
+func (m *GetPeople) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get People",
+		Description: "Get people by page and page size, ordered by name",
+		Method:      http.MethodGet,
+		Path:        "/v1/people",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetPeopleRequest{},
+		Response:    GetPeopleResponse{},
+	}
+}
+
+func (m *GetPeople) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		var req GetPeopleRequest
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetPeople) handle(ctx context.Context, r GetPeopleRequest) (*GetPeopleResponse, error) {
+	total, err := m.count(ctx)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	people, err := m.repo(ctx, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetPeopleResponse{TotalCount: total, People: people, Pagination: p}, nil
+}
+
+func (m *GetPeople) count(_ context.Context) (int, error) {
+	res, err := m.Repo.Scope("person").Query("SELECT RAW COUNT(*) FROM `person`.person.person", nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+
+	var count int
+	if err := res.One(&count); err != nil {
+		return 0, errors.Wrap(err, "row parse")
+	}
+	return count, nil
+}
+
+func (m *GetPeople) repo(_ context.Context, offset, limit int) ([]domain.Person, error) {
+	query := "SELECT RAW person FROM `person`.person.person ORDER BY person.Name, person.ID OFFSET $1 LIMIT $2"
+
+	rows, err := m.Repo.Scope("person").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{offset, limit},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	people := []domain.Person{}
+
+	for rows.Next() {
+		var person domain.Person
+
+		if err := rows.Row(&person); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+
+		people = append(people, person)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return people, nil
+}
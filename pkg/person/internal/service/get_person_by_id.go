@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/person/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetPersonByID struct {
+	Repo *gocb.Bucket
+}
+
+type GetPersonByIDRequest struct {
+	ID          int    `path:"id"`
+	IfNoneMatch string `header:"If-None-Match" description:"entity tag of the cached person"`
+}
+
+type GetPersonByIDResponse struct {
+	Person domain.Person `json:"person"`
+
+	etag        string
+	notModified bool
+}
+
+func NewGetPersonByID(repo *gocb.Bucket) *GetPersonByID {
+	return &GetPersonByID{Repo: repo}
+}
+
+func (m *GetPersonByID) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Person",
+		Description: "Get person by id",
+		Method:      http.MethodGet,
+		Path:        "/v1/people/{id}",
+		Headers:     map[string]string{"ETag": "entity tag of the person"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetPersonByIDRequest{},
+		Response:    GetPersonByIDResponse{},
+	}
+}
+
+func (m *GetPersonByID) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		res, err := m.handle(ctx, GetPersonByIDRequest{ID: id, IfNoneMatch: r.Header.Get("If-None-Match")})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		if res.notModified {
+			w.WriteHeader(http.StatusNotModified)
+			return nil, nil
+		}
+
+		return res, nil
+	}
+}
+
+func (m *GetPersonByID) handle(_ context.Context, r GetPersonByIDRequest) (*GetPersonByIDResponse, error) {
+	person, cas, err := getPerson(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	if r.IfNoneMatch != "" && etag.MatchWeak(r.IfNoneMatch, etag.Format(cas)) {
+		return &GetPersonByIDResponse{etag: etag.Format(cas), notModified: true}, nil
+	}
+
+	return &GetPersonByIDResponse{Person: *person, etag: etag.Format(cas)}, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/person/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type GetPersonCredits struct {
+	Repo *gocb.Bucket
+}
+
+type GetPersonCreditsRequest struct {
+	ID int `path:"id"`
+}
+
+type GetPersonCreditsResponse struct {
+	Credits domain.Credits `json:"credits"`
+}
+
+func NewGetPersonCredits(repo *gocb.Bucket) *GetPersonCredits {
+	return &GetPersonCredits{Repo: repo}
+}
+
+func (m *GetPersonCredits) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Person Credits",
+		Description: "Get cast and crew credits of the person, most recent release first",
+		Method:      http.MethodGet,
+		Path:        "/v1/people/{id}/credits",
+		Headers:     map[string]string{},
+		Handler:     m.endpoint(ctx),
+		Request:     GetPersonCreditsRequest{},
+		Response:    GetPersonCreditsResponse{},
+	}
+}
+
+func (m *GetPersonCredits) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		return m.handle(ctx, GetPersonCreditsRequest{ID: id})
+	}
+}
+
+func (m *GetPersonCredits) handle(_ context.Context, r GetPersonCreditsRequest) (*GetPersonCreditsResponse, error) {
+	_, _, err := getPerson(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	credits, err := getCredits(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	return &GetPersonCreditsResponse{Credits: *credits}, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/person/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+// getPerson returns the person with its cas value.
+func getPerson(repo *gocb.Bucket, id int) (*domain.Person, uint64, error) {
+	doc, err := repo.Scope("person").Collection("person").Get(strconv.Itoa(id), &gocb.GetOptions{
+		Timeout: time.Second * 3,
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "couchbase query")
+	}
+
+	var person domain.Person
+	if err := doc.Content(&person); err != nil {
+		return nil, 0, errors.Wrap(err, "row parse")
+	}
+
+	return &person, uint64(doc.Cas()), nil
+}
+
+// checkIfMatch validates the If-Match header of a write against the current cas value of the person.
+func checkIfMatch(ifMatch string, required bool, cas uint64) error {
+	if ifMatch == "" {
+		if required {
+			return errors.NewPreconditionRequiredError("If-Match header is required", "missing If-Match header")
+		}
+		return nil
+	}
+
+	if !etag.MatchStrong(ifMatch, cas) {
+		return errors.NewPreconditionFailedError("person has been modified", "If-Match mismatch")
+	}
+	return nil
+}
+
+// getCredits collects the credits of the person from the cast and crew of the movies.
+func getCredits(repo *gocb.Bucket, id int) (*domain.Credits, error) {
+	credits := &domain.Credits{Cast: []domain.CastCredit{}, Crew: []domain.CrewCredit{}}
+
+	query := "SELECT movie.ID AS MovieID, movie.Title, movie.ReleaseDate, c.CastOrder " +
+		"FROM `movie`.movie.movie UNNEST movie.Cast AS c " +
+		"WHERE ANY p IN movie.Cast SATISFIES p.PersonID = $1 END AND c.PersonID = $1 AND movie.DeletedAt IS NOT VALUED " +
+		"ORDER BY movie.ReleaseDate DESC, movie.ID"
+	if err := queryCredits(repo, query, id, &credits.Cast); err != nil {
+		return nil, errors.Wrap(err, "cast credits")
+	}
+
+	query = "SELECT movie.ID AS MovieID, movie.Title, movie.ReleaseDate, c.Department, c.Job " +
+		"FROM `movie`.movie.movie UNNEST movie.Crew AS c " +
+		"WHERE ANY p IN movie.Crew SATISFIES p.PersonID = $1 END AND c.PersonID = $1 AND movie.DeletedAt IS NOT VALUED " +
+		"ORDER BY movie.ReleaseDate DESC, movie.ID"
+	if err := queryCredits(repo, query, id, &credits.Crew); err != nil {
+		return nil, errors.Wrap(err, "crew credits")
+	}
+
+	return credits, nil
+}
+
+func queryCredits[T any](repo *gocb.Bucket, query string, id int, credits *[]T) error {
+	rows, err := repo.Scope("movie").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{id},
+	})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+
+	for rows.Next() {
+		var credit T
+		if err := rows.Row(&credit); err != nil {
+			return errors.Wrap(err, "row parse")
+		}
+		*credits = append(*credits, credit)
+	}
+
+	return rows.Err()
+}
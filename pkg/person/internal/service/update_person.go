@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/person/internal/domain"
+	"github.com/couchbase/gocb/v2"
+	"github.com/go-chi/chi"
+)
+
+type UpdatePerson struct {
+	Repo           *gocb.Bucket
+	RequireIfMatch bool
+}
+
+type UpdatePersonRequest struct {
+	ID      int           `path:"id"`
+	IfMatch string        `header:"If-Match" description:"entity tag the person must still have"`
+	Person  domain.Person `json:"person"`
+}
+
+type UpdatePersonResponse struct {
+	etag string
+}
+
+func NewUpdatePerson(repo *gocb.Bucket, requireIfMatch bool) *UpdatePerson {
+	return &UpdatePerson{Repo: repo, RequireIfMatch: requireIfMatch}
+}
+
+func (m *UpdatePerson) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Update Person",
+		Description: "Update person by id",
+		Method:      http.MethodPut,
+		Path:        "/v1/people/{id}",
+		Headers:     map[string]string{"ETag": "entity tag of the updated person"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     UpdatePersonRequest{},
+		Response:    UpdatePersonResponse{},
+	}
+}
+
+func (m *UpdatePerson) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		idStr := chi.URLParam(r, "id")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var person domain.Person
+
+		err = json.Unmarshal(body, &person)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "person body unmarshal").Error())
+		}
+
+		res, err := m.handle(ctx, UpdatePersonRequest{ID: id, IfMatch: r.Header.Get("If-Match"), Person: person})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *UpdatePerson) handle(ctx context.Context, r UpdatePersonRequest) (*UpdatePersonResponse, error) {
+	if r.Person.ID != 0 && r.Person.ID != r.ID {
+		return nil, errors.NewBadRequestError("person id does not match the path id", "body id and path id mismatch")
+	}
+	r.Person.ID = r.ID
+
+	err := r.Person.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	_, cas, err := getPerson(m.Repo, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	cas, err = m.repo(ctx, r.Person, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	return &UpdatePersonResponse{etag: etag.Format(cas)}, nil
+}
+
+func (m *UpdatePerson) repo(_ context.Context, person domain.Person, cas uint64) (uint64, error) {
+	res, err := m.Repo.Scope("person").Collection("person").Replace(strconv.Itoa(person.ID), person, &gocb.ReplaceOptions{
+		Cas:     gocb.Cas(cas),
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+	return uint64(res.Cas()), nil
+}
@@ -0,0 +1,43 @@
+package taxonomy
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/config"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/service"
+	"github.com/couchbase/gocb/v2"
+)
+
+func InitController(ctx context.Context, c config.Config, db *gocb.Bucket, pq *sql.DB) application.Controller {
+	repo := newRepository(c, db, pq)
+	if err := repo.Seed(ctx); err != nil {
+		log.Printf("taxonomy id seed: %s", err)
+	}
+
+	getTermsSvc := service.NewGetTerms(repo)
+	addTermSvc := service.NewAddTerm(repo)
+	getTermByIDSvc := service.NewGetTermByID(repo)
+	renameTermSvc := service.NewRenameTerm(repo, c.Application.RequireIfMatch)
+	addTermAliasSvc := service.NewAddTermAlias(repo, c.Application.RequireIfMatch)
+	mergeTermSvc := service.NewMergeTerm(repo, c.Application.RequireIfMatch)
+	deprecateTermSvc := service.NewDeprecateTerm(repo, c.Application.RequireIfMatch)
+	getRewriteSvc := service.NewGetRewrite(repo)
+
+	return application.Controller{
+		Name:        "Taxonomy",
+		Description: "Genre and keyword management",
+		Routes: []application.Route{
+			getTermsSvc.Route(ctx),
+			addTermSvc.Route(ctx),
+			getTermByIDSvc.Route(ctx),
+			renameTermSvc.Route(ctx),
+			addTermAliasSvc.Route(ctx),
+			mergeTermSvc.Route(ctx),
+			deprecateTermSvc.Route(ctx),
+			getRewriteSvc.Route(ctx),
+		},
+	}
+}
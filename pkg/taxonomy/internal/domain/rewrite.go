@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+const (
+	RewritePending = "pending"
+	RewriteDone    = "done"
+	RewriteFailed  = "failed"
+)
+
+// Rewrite replaces the From values of the movies with Into. It is queued when terms are renamed,
+// merged or aliased, and carried out in the background.
+type Rewrite struct {
+	ID        int       `json:"ID"`
+	Kind      string    `json:"Kind"`
+	From      []string  `json:"From"`
+	Into      string    `json:"Into"`
+	Status    string    `json:"Status"`
+	Movies    int       `json:"Movies"`
+	Error     string    `json:"Error,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/termkey"
+)
+
+const (
+	KindGenre   = "genre"
+	KindKeyword = "keyword"
+)
+
+// Kinds are the managed vocabularies. Genres are closed, movies can only use known genres,
+// while unknown keywords are kept as they are.
+var Kinds = []string{KindGenre, KindKeyword}
+
+// Term is the canonical spelling of a genre or keyword. Movies using one of its aliases are
+// normalised to the name. Deprecated terms stay on the movies using them but cannot be assigned anymore.
+type Term struct {
+	ID         int      `json:"ID"`
+	Kind       string   `json:"Kind"`
+	Name       string   `json:"Name"`
+	Aliases    []string `json:"Aliases"`
+	Deprecated bool     `json:"Deprecated"`
+}
+
+func (t Term) Validate() error {
+	if Key(t.Name) == "" {
+		return errors.New("term name must contain a letter or digit")
+	}
+
+	seen := map[string]bool{Key(t.Name): true}
+	for _, a := range t.Aliases {
+		k := Key(a)
+		if k == "" {
+			return errors.New("term alias must contain a letter or digit")
+		}
+		if seen[k] {
+			return errors.New("term alias " + a + " is a duplicate")
+		}
+		seen[k] = true
+	}
+	return nil
+}
+
+// Keys returns the lookup keys of the name and the aliases.
+func (t Term) Keys() []string {
+	keys := []string{Key(t.Name)}
+	for _, a := range t.Aliases {
+		keys = append(keys, Key(a))
+	}
+	return keys
+}
+
+// Key folds the spellings of a term onto one lookup key: "Sci-Fi", "sci fi" and "SciFi" share "scifi".
+func Key(s string) string {
+	return termkey.Fold(s)
+}
+
+func IsKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize maps the values onto the names of the matching terms, dropping duplicates.
+// Values of deprecated terms and, for closed kinds, values without a term are reported unless
+// they are already among the kept values, which the resource held before.
+func Normalize(kind string, terms []Term, values, kept []string) (normalized, unknown, deprecated []string) {
+	byKey := map[string]Term{}
+	for _, t := range terms {
+		for _, k := range t.Keys() {
+			byKey[k] = t
+		}
+	}
+
+	keep := map[string]bool{}
+	for _, v := range kept {
+		keep[Key(v)] = true
+	}
+
+	seen := map[string]bool{}
+	for _, v := range values {
+		name := strings.TrimSpace(v)
+
+		t, ok := byKey[Key(v)]
+		switch {
+		case ok && t.Deprecated && !keep[Key(v)]:
+			deprecated = append(deprecated, v)
+			continue
+		case ok:
+			name = t.Name
+		case kind == KindGenre && !keep[Key(v)]:
+			unknown = append(unknown, v)
+			continue
+		case name == "":
+			continue
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, unknown, deprecated
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	terms := []Term{
+		{ID: 1, Name: "Science Fiction", Aliases: []string{"Sci-Fi"}},
+		{ID: 2, Name: "Drama"},
+		{ID: 3, Name: "Film-Noir", Deprecated: true},
+	}
+
+	testCases := map[string]struct {
+		Kind       string
+		Values     []string
+		Kept       []string
+		Normalized []string
+		Unknown    []string
+		Deprecated []string
+	}{
+		"should map aliases and spellings onto the name": {
+			Kind:       KindGenre,
+			Values:     []string{"scifi", "SCI FI", "drama"},
+			Normalized: []string{"Science Fiction", "Drama"},
+		},
+		"should report unknown genres": {
+			Kind:       KindGenre,
+			Values:     []string{"Drama", "Western"},
+			Normalized: []string{"Drama"},
+			Unknown:    []string{"Western"},
+		},
+		"should keep unknown keywords": {
+			Kind:       KindKeyword,
+			Values:     []string{" heist ", "sci-fi", ""},
+			Normalized: []string{"heist", "Science Fiction"},
+		},
+		"should report deprecated terms": {
+			Kind:       KindGenre,
+			Values:     []string{"film noir"},
+			Deprecated: []string{"film noir"},
+		},
+		"should keep unknown and deprecated genres the movie already had": {
+			Kind:       KindGenre,
+			Values:     []string{"western", "Film Noir", "Musical"},
+			Kept:       []string{"Western", "Film-Noir"},
+			Normalized: []string{"western", "Film-Noir"},
+			Unknown:    []string{"Musical"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			normalized, unknown, deprecated := Normalize(tc.Kind, terms, tc.Values, tc.Kept)
+
+			assert.Equal(t, tc.Normalized, normalized)
+			assert.Equal(t, tc.Unknown, unknown)
+			assert.Equal(t, tc.Deprecated, deprecated)
+		})
+	}
+}
+
+func TestTerm_Validate(t *testing.T) {
+	assert.NoError(t, Term{Name: "Science Fiction", Aliases: []string{"Sci-Fi"}}.Validate())
+	assert.EqualError(t, Term{Name: "--"}.Validate(), "term name must contain a letter or digit")
+	assert.EqualError(t, Term{Name: "Sci-Fi", Aliases: []string{"scifi"}}.Validate(), "term alias scifi is a duplicate")
+}
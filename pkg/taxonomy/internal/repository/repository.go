@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/sequence"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/couchbase/gocb/v2"
+)
+
+// Couchbase stores the terms and the queued rewrites in the taxonomy scope.
+type Couchbase struct {
+	repo       *gocb.Bucket
+	termIDs    sequence.Sequence
+	rewriteIDs sequence.Sequence
+}
+
+// termDoc keeps the lookup keys of the term next to it, so that terms are found by any spelling.
+type termDoc struct {
+	domain.Term
+	Keys []string `json:"Keys"`
+}
+
+func NewCouchbase(repo *gocb.Bucket, termIDs, rewriteIDs sequence.Sequence) *Couchbase {
+	return &Couchbase{repo: repo, termIDs: termIDs, rewriteIDs: rewriteIDs}
+}
+
+func (c *Couchbase) terms() *gocb.Collection {
+	return c.repo.Scope("taxonomy").Collection("term")
+}
+
+func (c *Couchbase) rewrites() *gocb.Collection {
+	return c.repo.Scope("taxonomy").Collection("rewrite")
+}
+
+// Seed moves the term and rewrite sequences past the stored documents.
+func (c *Couchbase) Seed(ctx context.Context) error {
+	if err := sequence.Seed(ctx, c.termIDs, c.repo, "taxonomy", "term"); err != nil {
+		return errors.Wrap(err, "term ids")
+	}
+	if err := sequence.Seed(ctx, c.rewriteIDs, c.repo, "taxonomy", "rewrite"); err != nil {
+		return errors.Wrap(err, "rewrite ids")
+	}
+	return nil
+}
+
+// Get returns the term of the kind with its cas value.
+func (c *Couchbase) Get(_ context.Context, kind string, id int) (*domain.Term, uint64, error) {
+	doc, err := c.terms().Get(strconv.Itoa(id), &gocb.GetOptions{Timeout: 3 * time.Second})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "couchbase query")
+	}
+
+	var t termDoc
+	if err := doc.Content(&t); err != nil {
+		return nil, 0, errors.Wrap(err, "row parse")
+	}
+
+	if t.Kind != kind {
+		return nil, 0, errors.Wrap(errors.ErrNotFound, "term of another kind")
+	}
+	return &t.Term, uint64(doc.Cas()), nil
+}
+
+// Find returns the terms of the kind whose name or aliases fold onto one of the keys.
+func (c *Couchbase) Find(ctx context.Context, kind string, keys []string) ([]domain.Term, error) {
+	query := "SELECT RAW t FROM `taxonomy`.taxonomy.term AS t WHERE t.Kind = $1 AND ANY k IN t.Keys SATISFIES k IN $2 END"
+
+	return c.query(ctx, query, kind, keys)
+}
+
+// List returns a page of the terms of the kind ordered by name.
+func (c *Couchbase) List(ctx context.Context, kind string, offset, limit int) ([]domain.Term, error) {
+	query := "SELECT RAW t FROM `taxonomy`.taxonomy.term AS t WHERE t.Kind = $1 ORDER BY t.Name, t.ID OFFSET $2 LIMIT $3"
+
+	return c.query(ctx, query, kind, offset, limit)
+}
+
+func (c *Couchbase) Count(ctx context.Context, kind string) (int, error) {
+	res, err := c.repo.Scope("taxonomy").Query("SELECT RAW COUNT(*) FROM `taxonomy`.taxonomy.term AS t WHERE t.Kind = $1", &gocb.QueryOptions{
+		PositionalParameters: []interface{}{kind},
+		Context:              ctx,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+
+	var count int
+	if err := res.One(&count); err != nil {
+		return 0, errors.Wrap(err, "row parse")
+	}
+	return count, nil
+}
+
+func (c *Couchbase) query(ctx context.Context, query string, params ...interface{}) ([]domain.Term, error) {
+	rows, err := c.repo.Scope("taxonomy").Query(query, &gocb.QueryOptions{
+		PositionalParameters: params,
+		Context:              ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	terms := []domain.Term{}
+
+	for rows.Next() {
+		var t termDoc
+		if err := rows.Row(&t); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+		terms = append(terms, t.Term)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+	return terms, nil
+}
+
+// Insert allocates the id of the term and stores it.
+func (c *Couchbase) Insert(ctx context.Context, t *domain.Term) error {
+	_, err := sequence.Insert(ctx, c.termIDs, func(id int) error {
+		t.ID = id
+		_, err := c.terms().Insert(strconv.Itoa(t.ID), termDoc{Term: *t, Keys: t.Keys()}, &gocb.InsertOptions{Timeout: 5 * time.Second})
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
+
+// Replace stores the term if it still has the cas value and returns the new cas value.
+func (c *Couchbase) Replace(_ context.Context, t domain.Term, cas uint64) (uint64, error) {
+	res, err := c.terms().Replace(strconv.Itoa(t.ID), termDoc{Term: t, Keys: t.Keys()}, &gocb.ReplaceOptions{
+		Cas:     gocb.Cas(cas),
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "couchbase query")
+	}
+	return uint64(res.Cas()), nil
+}
+
+func (c *Couchbase) Remove(_ context.Context, id int, cas uint64) error {
+	_, err := c.terms().Remove(strconv.Itoa(id), &gocb.RemoveOptions{Cas: gocb.Cas(cas), Timeout: 5 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
+
+// Queue stores a pending rewrite of the movies from the values onto the name.
+func (c *Couchbase) Queue(ctx context.Context, kind string, from []string, into string) (*domain.Rewrite, error) {
+	now := time.Now().UTC()
+	rw := &domain.Rewrite{
+		Kind:      kind,
+		From:      from,
+		Into:      into,
+		Status:    domain.RewritePending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	_, err := sequence.Insert(ctx, c.rewriteIDs, func(id int) error {
+		rw.ID = id
+		_, err := c.rewrites().Insert(strconv.Itoa(id), rw, &gocb.InsertOptions{Timeout: 5 * time.Second})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+	return rw, nil
+}
+
+func (c *Couchbase) GetRewrite(_ context.Context, id int) (*domain.Rewrite, error) {
+	doc, err := c.rewrites().Get(strconv.Itoa(id), &gocb.GetOptions{Timeout: 3 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	var rw domain.Rewrite
+	if err := doc.Content(&rw); err != nil {
+		return nil, errors.Wrap(err, "row parse")
+	}
+	return &rw, nil
+}
+
+// Pending returns the pending rewrites in the order they were queued.
+func (c *Couchbase) Pending(ctx context.Context) ([]domain.Rewrite, error) {
+	query := "SELECT RAW r FROM `taxonomy`.taxonomy.rewrite AS r WHERE r.Status = $1 ORDER BY r.ID"
+
+	rows, err := c.repo.Scope("taxonomy").Query(query, &gocb.QueryOptions{
+		PositionalParameters: []interface{}{domain.RewritePending},
+		Context:              ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couchbase query")
+	}
+
+	var rewrites []domain.Rewrite
+
+	for rows.Next() {
+		var rw domain.Rewrite
+		if err := rows.Row(&rw); err != nil {
+			return nil, errors.Wrap(err, "row parse")
+		}
+		rewrites = append(rewrites, rw)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+	return rewrites, nil
+}
+
+// Finish records the outcome of the rewrite.
+func (c *Couchbase) Finish(_ context.Context, rw domain.Rewrite, movies int, failure error) error {
+	rw.Status, rw.Movies, rw.UpdatedAt = domain.RewriteDone, movies, time.Now().UTC()
+	if failure != nil {
+		rw.Status, rw.Error = domain.RewriteFailed, failure.Error()
+	}
+
+	_, err := c.rewrites().Replace(strconv.Itoa(rw.ID), rw, &gocb.ReplaceOptions{Timeout: 5 * time.Second})
+	if err != nil {
+		return errors.Wrap(err, "couchbase query")
+	}
+	return nil
+}
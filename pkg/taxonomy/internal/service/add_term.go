@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type AddTerm struct {
+	Repo *repository.Couchbase
+}
+
+type AddTermRequest struct {
+	Kind string      `path:"kind" description:"genre or keyword"`
+	Term domain.Term `json:"term"`
+}
+
+type AddTermResponse struct {
+	ID int `json:"id"`
+}
+
+func NewAddTerm(repo *repository.Couchbase) *AddTerm {
+	return &AddTerm{Repo: repo}
+}
+
+func (m *AddTerm) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Add Term",
+		Description: "Add genre or keyword, its name and aliases must not be a spelling of another term",
+		Method:      http.MethodPost,
+		Path:        "/v1/taxonomy/{kind}",
+		Headers:     map[string]string{"Location": "path of the created term"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     AddTermRequest{},
+		Response:    AddTermResponse{},
+	}
+}
+
+func (m *AddTerm) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		kind, err := parseKind(chi.URLParam(r, "kind"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var term domain.Term
+
+		err = json.Unmarshal(body, &term)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "term body unmarshal").Error())
+		}
+
+		res, err := m.handle(ctx, AddTermRequest{Kind: kind, Term: term})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", "/v1/taxonomy/"+kind+"/"+strconv.Itoa(res.ID))
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+func (m *AddTerm) handle(ctx context.Context, r AddTermRequest) (*AddTermResponse, error) {
+	if r.Term.ID != 0 {
+		return nil, errors.NewBadRequestError("term id is assigned by the server", "client supplied term id")
+	}
+	r.Term.Kind = r.Kind
+	if r.Term.Aliases == nil {
+		r.Term.Aliases = []string{}
+	}
+
+	err := r.Term.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = checkTaken(ctx, m.Repo, r.Term)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Repo.Insert(ctx, &r.Term)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	return &AddTermResponse{ID: r.Term.ID}, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+)
+
+type AddTermAlias struct {
+	Repo           *repository.Couchbase
+	RequireIfMatch bool
+}
+
+type AddTermAliasRequest struct {
+	Kind    string `path:"kind" description:"genre or keyword"`
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the term must still have"`
+	Alias   string `json:"Alias"`
+}
+
+func NewAddTermAlias(repo *repository.Couchbase, requireIfMatch bool) *AddTermAlias {
+	return &AddTermAlias{Repo: repo, RequireIfMatch: requireIfMatch}
+}
+
+func (m *AddTermAlias) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Add Term Alias",
+		Description: "Add alias to genre or keyword, movies using the alias are rewritten in the background",
+		Method:      http.MethodPost,
+		Path:        "/v1/taxonomy/{kind}/{id}/aliases",
+		Headers:     map[string]string{"ETag": "entity tag of the updated term", "Location": "path of the queued rewrite"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     AddTermAliasRequest{},
+		Response:    TermRewriteResponse{},
+	}
+}
+
+func (m *AddTermAlias) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		kind, id, err := termPath(r)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		req := AddTermAliasRequest{Kind: kind, ID: id, IfMatch: r.Header.Get("If-Match")}
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "alias body unmarshal").Error())
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.write(w)
+		return res, nil
+	}
+}
+
+func (m *AddTermAlias) handle(ctx context.Context, r AddTermAliasRequest) (*TermRewriteResponse, error) {
+	term, cas, err := m.Repo.Get(ctx, r.Kind, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	term.Aliases = append(term.Aliases, r.Alias)
+
+	return changeTerm(ctx, m.Repo, *term, cas, []string{r.Alias})
+}
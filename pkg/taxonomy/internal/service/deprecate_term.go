@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+)
+
+type DeprecateTerm struct {
+	Repo           *repository.Couchbase
+	RequireIfMatch bool
+}
+
+type DeprecateTermRequest struct {
+	Kind    string `path:"kind" description:"genre or keyword"`
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the term must still have"`
+}
+
+type DeprecateTermResponse struct {
+	Term domain.Term `json:"term"`
+
+	etag string
+}
+
+func NewDeprecateTerm(repo *repository.Couchbase, requireIfMatch bool) *DeprecateTerm {
+	return &DeprecateTerm{Repo: repo, RequireIfMatch: requireIfMatch}
+}
+
+func (m *DeprecateTerm) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Deprecate Term",
+		Description: "Deprecate genre or keyword, movies keep it but it cannot be assigned anymore",
+		Method:      http.MethodPost,
+		Path:        "/v1/taxonomy/{kind}/{id}/deprecate",
+		Headers:     map[string]string{"ETag": "entity tag of the updated term"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     DeprecateTermRequest{},
+		Response:    DeprecateTermResponse{},
+	}
+}
+
+func (m *DeprecateTerm) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		kind, id, err := termPath(r)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, DeprecateTermRequest{Kind: kind, ID: id, IfMatch: r.Header.Get("If-Match")})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *DeprecateTerm) handle(ctx context.Context, r DeprecateTermRequest) (*DeprecateTermResponse, error) {
+	term, cas, err := m.Repo.Get(ctx, r.Kind, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	term.Deprecated = true
+
+	cas, err = m.Repo.Replace(ctx, *term, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	return &DeprecateTermResponse{Term: *term, etag: etag.Format(cas)}, nil
+}
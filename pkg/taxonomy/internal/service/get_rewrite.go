@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type GetRewrite struct {
+	Repo *repository.Couchbase
+}
+
+type GetRewriteRequest struct {
+	ID int `path:"id"`
+}
+
+type GetRewriteResponse struct {
+	Rewrite domain.Rewrite `json:"rewrite"`
+}
+
+func NewGetRewrite(repo *repository.Couchbase) *GetRewrite {
+	return &GetRewrite{Repo: repo}
+}
+
+func (m *GetRewrite) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Rewrite",
+		Description: "Get status of the background rewrite of the movies after a term change",
+		Method:      http.MethodGet,
+		Path:        "/v1/taxonomy/rewrites/{id}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     GetRewriteRequest{},
+		Response:    GetRewriteResponse{},
+	}
+}
+
+func (m *GetRewrite) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+		}
+
+		return m.handle(ctx, GetRewriteRequest{ID: id})
+	}
+}
+
+func (m *GetRewrite) handle(ctx context.Context, r GetRewriteRequest) (*GetRewriteResponse, error) {
+	rw, err := m.Repo.GetRewrite(ctx, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetRewriteResponse{Rewrite: *rw}, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+)
+
+type GetTermByID struct {
+	Repo *repository.Couchbase
+}
+
+type GetTermByIDRequest struct {
+	Kind string `path:"kind" description:"genre or keyword"`
+	ID   int    `path:"id"`
+}
+
+type GetTermByIDResponse struct {
+	Term domain.Term `json:"term"`
+
+	etag string
+}
+
+func NewGetTermByID(repo *repository.Couchbase) *GetTermByID {
+	return &GetTermByID{Repo: repo}
+}
+
+func (m *GetTermByID) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Term",
+		Description: "Get genre or keyword by id",
+		Method:      http.MethodGet,
+		Path:        "/v1/taxonomy/{kind}/{id}",
+		Headers:     map[string]string{"ETag": "entity tag of the term"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetTermByIDRequest{},
+		Response:    GetTermByIDResponse{},
+	}
+}
+
+func (m *GetTermByID) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		kind, id, err := termPath(r)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, GetTermByIDRequest{Kind: kind, ID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("ETag", res.etag)
+		return res, nil
+	}
+}
+
+func (m *GetTermByID) handle(ctx context.Context, r GetTermByIDRequest) (*GetTermByIDResponse, error) {
+	term, cas, err := m.Repo.Get(ctx, r.Kind, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetTermByIDResponse{Term: *term, etag: etag.Format(cas)}, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type GetTerms struct {
+	Repo *repository.Couchbase
+}
+
+type GetTermsRequest struct {
+	Kind string `path:"kind" description:"genre or keyword"`
+	pagination.Request
+}
+
+type GetTermsResponse struct {
+	TotalCount int              `json:"totalCount"`
+	Terms      []domain.Term    `json:"terms"`
+	Pagination pagination.Model `json:"pagination"`
+}
+
+func NewGetTerms(repo *repository.Couchbase) *GetTerms {
+	return &GetTerms{Repo: repo}
+}
+
+func (m *GetTerms) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Terms",
+		Description: "Get genres or keywords by page and page size, ordered by name",
+		Method:      http.MethodGet,
+		Path:        "/v1/taxonomy/{kind}",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Handler:     m.endpoint(ctx),
+		Request:     GetTermsRequest{},
+		Response:    GetTermsResponse{},
+	}
+}
+
+func (m *GetTerms) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		kind, err := parseKind(chi.URLParam(r, "kind"))
+		if err != nil {
+			return nil, err
+		}
+
+		req := GetTermsRequest{Kind: kind}
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetTerms) handle(ctx context.Context, r GetTermsRequest) (*GetTermsResponse, error) {
+	total, err := m.Repo.Count(ctx, r.Kind)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	terms, err := m.Repo.List(ctx, r.Kind, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+	return &GetTermsResponse{TotalCount: total, Terms: terms, Pagination: p}, nil
+}
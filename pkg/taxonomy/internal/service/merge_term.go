@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+)
+
+type MergeTerm struct {
+	Repo           *repository.Couchbase
+	RequireIfMatch bool
+}
+
+type MergeTermRequest struct {
+	Kind    string `path:"kind" description:"genre or keyword"`
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the merged term must still have"`
+	Into    int    `json:"Into"`
+}
+
+func NewMergeTerm(repo *repository.Couchbase, requireIfMatch bool) *MergeTerm {
+	return &MergeTerm{Repo: repo, RequireIfMatch: requireIfMatch}
+}
+
+func (m *MergeTerm) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Merge Term",
+		Description: "Merge genre or keyword into another one, its spellings become aliases and movies are rewritten in the background",
+		Method:      http.MethodPost,
+		Path:        "/v1/taxonomy/{kind}/{id}/merge",
+		Headers:     map[string]string{"ETag": "entity tag of the term merged into", "Location": "path of the queued rewrite"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     MergeTermRequest{},
+		Response:    TermRewriteResponse{},
+	}
+}
+
+func (m *MergeTerm) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		kind, id, err := termPath(r)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		req := MergeTermRequest{Kind: kind, ID: id, IfMatch: r.Header.Get("If-Match")}
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "merge body unmarshal").Error())
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.write(w)
+		return res, nil
+	}
+}
+
+// handle extends the target before removing the merged term, so that a failure in between
+// leaves both terms rather than losing the spellings.
+func (m *MergeTerm) handle(ctx context.Context, r MergeTermRequest) (*TermRewriteResponse, error) {
+	if r.Into == r.ID {
+		return nil, errors.NewBadRequestError("term cannot be merged into itself", "merge into itself")
+	}
+
+	source, sourceCas, err := m.Repo.Get(ctx, r.Kind, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, sourceCas)
+	if err != nil {
+		return nil, err
+	}
+
+	target, cas, err := m.Repo.Get(ctx, r.Kind, r.Into)
+	if err != nil {
+		if e := errors.Translate(err); e.StatusCode == http.StatusNotFound {
+			return nil, errors.NewBadRequestError("term to merge into does not exist", errors.Wrap(err, "merge target").Error())
+		}
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	from := append([]string{source.Name}, source.Aliases...)
+	target.Aliases = append(target.Aliases, from...)
+
+	cas, err = m.Repo.Replace(ctx, *target, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = m.Repo.Remove(ctx, source.ID, sourceCas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	rw, err := m.Repo.Queue(ctx, r.Kind, from, target.Name)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "rewrite queue"))
+	}
+
+	return &TermRewriteResponse{Term: *target, Rewrite: *rw, etag: etag.Format(cas)}, nil
+}
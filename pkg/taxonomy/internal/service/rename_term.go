@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+)
+
+type RenameTerm struct {
+	Repo           *repository.Couchbase
+	RequireIfMatch bool
+}
+
+type RenameTermRequest struct {
+	Kind    string `path:"kind" description:"genre or keyword"`
+	ID      int    `path:"id"`
+	IfMatch string `header:"If-Match" description:"entity tag the term must still have"`
+	Name    string `json:"Name"`
+}
+
+// TermRewriteResponse is the term after a change which rewrites the movies in the background.
+type TermRewriteResponse struct {
+	Term    domain.Term    `json:"term"`
+	Rewrite domain.Rewrite `json:"rewrite"`
+
+	etag string
+}
+
+func NewRenameTerm(repo *repository.Couchbase, requireIfMatch bool) *RenameTerm {
+	return &RenameTerm{Repo: repo, RequireIfMatch: requireIfMatch}
+}
+
+func (m *RenameTerm) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Rename Term",
+		Description: "Rename genre or keyword, the old name stays an alias and movies are rewritten in the background",
+		Method:      http.MethodPost,
+		Path:        "/v1/taxonomy/{kind}/{id}/rename",
+		Headers:     map[string]string{"ETag": "entity tag of the updated term", "Location": "path of the queued rewrite"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth, middleware.Admin},
+		Handler:     m.endpoint(ctx),
+		Request:     RenameTermRequest{},
+		Response:    TermRewriteResponse{},
+	}
+}
+
+func (m *RenameTerm) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		kind, id, err := termPath(r)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		req := RenameTermRequest{Kind: kind, ID: id, IfMatch: r.Header.Get("If-Match")}
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "rename body unmarshal").Error())
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.write(w)
+		return res, nil
+	}
+}
+
+func (m *RenameTerm) handle(ctx context.Context, r RenameTermRequest) (*TermRewriteResponse, error) {
+	term, cas, err := m.Repo.Get(ctx, r.Kind, r.ID)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	err = checkIfMatch(r.IfMatch, m.RequireIfMatch, cas)
+	if err != nil {
+		return nil, err
+	}
+
+	old := term.Name
+	term.Name = r.Name
+	if domain.Key(old) != domain.Key(r.Name) {
+		term.Aliases = append(term.Aliases, old)
+	}
+
+	return changeTerm(ctx, m.Repo, *term, cas, []string{old})
+}
+
+// changeTerm stores the changed term and queues the rewrite of the movies using the values.
+func changeTerm(ctx context.Context, repo *repository.Couchbase, term domain.Term, cas uint64, from []string) (*TermRewriteResponse, error) {
+	err := term.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = checkTaken(ctx, repo, term)
+	if err != nil {
+		return nil, err
+	}
+
+	cas, err = repo.Replace(ctx, term, cas)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	rw, err := repo.Queue(ctx, term.Kind, from, term.Name)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "rewrite queue"))
+	}
+
+	return &TermRewriteResponse{Term: term, Rewrite: *rw, etag: etag.Format(cas)}, nil
+}
+
+func (r *TermRewriteResponse) write(w http.ResponseWriter) {
+	w.Header().Set("ETag", r.etag)
+	w.Header().Set("Location", "/v1/taxonomy/rewrites/"+strconv.Itoa(r.Rewrite.ID))
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/etag"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+// termPath parses the kind and the id of a term route.
+func termPath(r *http.Request) (string, int, error) {
+	kind, err := parseKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		return "", 0, err
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return "", 0, errors.NewBadRequestError("id must be integer", errors.Wrap(err, "id conversion").Error())
+	}
+	return kind, id, nil
+}
+
+func parseKind(kind string) (string, error) {
+	if !domain.IsKind(kind) {
+		return "", errors.NewNotFoundError("taxonomy kind must be genre or keyword", "unknown taxonomy kind "+kind)
+	}
+	return kind, nil
+}
+
+// checkTaken reports a spelling which already belongs to another term of the kind.
+func checkTaken(ctx context.Context, repo *repository.Couchbase, t domain.Term) error {
+	terms, err := repo.Find(ctx, t.Kind, t.Keys())
+	if err != nil {
+		return errors.Translate(errors.Wrap(err, "couchbase query"))
+	}
+
+	for _, other := range terms {
+		if other.ID != t.ID {
+			return errors.NewConflictError("spelling is taken by the term "+other.Name, "term "+strconv.Itoa(other.ID)+" shares a key")
+		}
+	}
+	return nil
+}
+
+// checkIfMatch validates the If-Match header of a write against the current cas value of the term.
+func checkIfMatch(ifMatch string, required bool, cas uint64) error {
+	if ifMatch == "" {
+		if required {
+			return errors.NewPreconditionRequiredError("If-Match header is required", "missing If-Match header")
+		}
+		return nil
+	}
+
+	if !etag.MatchStrong(ifMatch, cas) {
+		return errors.NewPreconditionFailedError("term has been modified", "If-Match mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package taxonomy
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/3n0ugh/allotropes/internal/config"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/sequence"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/taxonomy/internal/repository"
+	"github.com/couchbase/gocb/v2"
+)
+
+// Vocabulary normalises the genres and keywords of resources and hands out the rewrites
+// queued by term changes to the resources using the terms.
+type Vocabulary struct {
+	repo *repository.Couchbase
+}
+
+func NewVocabulary(c config.Config, db *gocb.Bucket, pq *sql.DB) *Vocabulary {
+	return &Vocabulary{repo: newRepository(c, db, pq)}
+}
+
+func newRepository(c config.Config, db *gocb.Bucket, pq *sql.DB) *repository.Couchbase {
+	return repository.NewCouchbase(db,
+		sequence.New(c.Application.MovieIDSource, "term", db, pq),
+		sequence.New(c.Application.MovieIDSource, "rewrite", db, pq),
+	)
+}
+
+// Normalize returns the values spelled like their terms. Unknown genres and deprecated terms
+// are rejected with a bad request error, unless the resource already held them.
+func (v *Vocabulary) Normalize(ctx context.Context, kind string, values, kept []string) ([]string, error) {
+	if len(values) == 0 {
+		return values, nil
+	}
+
+	keys := make([]string, len(values))
+	for i, value := range values {
+		keys[i] = domain.Key(value)
+	}
+
+	terms, err := v.repo.Find(ctx, kind, keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "term lookup")
+	}
+
+	normalized, unknown, deprecated := domain.Normalize(kind, terms, values, kept)
+	if len(unknown) > 0 {
+		return nil, errors.NewBadRequestError("unknown "+kind+"s: "+strings.Join(unknown, ", "), "unknown "+kind)
+	}
+	if len(deprecated) > 0 {
+		return nil, errors.NewBadRequestError("deprecated "+kind+"s: "+strings.Join(deprecated, ", "), "deprecated "+kind)
+	}
+
+	if normalized == nil {
+		normalized = []string{}
+	}
+	return normalized, nil
+}
+
+// Rewrites runs the pending rewrites in the order they were queued and records their outcome.
+// The rewrite function replaces the from values with into and returns the number of changed resources.
+func (v *Vocabulary) Rewrites(ctx context.Context, rewrite func(ctx context.Context, kind string, from []string, into string) (int, error)) error {
+	pending, err := v.repo.Pending(ctx)
+	if err != nil {
+		return errors.Wrap(err, "pending rewrites")
+	}
+
+	for _, rw := range pending {
+		n, failure := rewrite(ctx, rw.Kind, rw.From, rw.Into)
+
+		if err := v.repo.Finish(ctx, rw, n, failure); err != nil {
+			return errors.Wrap(err, "finish rewrite")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package watchlist
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/service"
+)
+
+func InitController(ctx context.Context, pq *sql.DB, movies catalog.Movies) application.Controller {
+	repo := repository.NewPostgreSQL(pq)
+
+	getWatchlistsSvc := service.NewGetWatchlists(repo)
+	addWatchlistSvc := service.NewAddWatchlist(repo)
+	renameWatchlistSvc := service.NewRenameWatchlist(repo)
+	deleteWatchlistSvc := service.NewDeleteWatchlist(repo)
+	getWatchlistEntriesSvc := service.NewGetWatchlistEntries(repo, movies)
+	putWatchlistEntrySvc := service.NewPutWatchlistEntry(repo, movies)
+	deleteWatchlistEntrySvc := service.NewDeleteWatchlistEntry(repo)
+	markEntryWatchedSvc := service.NewMarkEntryWatched(repo)
+	unmarkEntryWatchedSvc := service.NewUnmarkEntryWatched(repo)
+
+	return application.Controller{
+		Name:        "Watchlist",
+		Description: "Personal movie lists",
+		Routes: []application.Route{
+			getWatchlistsSvc.Route(ctx),
+			addWatchlistSvc.Route(ctx),
+			renameWatchlistSvc.Route(ctx),
+			deleteWatchlistSvc.Route(ctx),
+			getWatchlistEntriesSvc.Route(ctx),
+			putWatchlistEntrySvc.Route(ctx),
+			deleteWatchlistEntrySvc.Route(ctx),
+			markEntryWatchedSvc.Route(ctx),
+			unmarkEntryWatchedSvc.Route(ctx),
+		},
+	}
+}
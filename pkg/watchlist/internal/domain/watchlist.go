@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+)
+
+// Names of the lists every user has. They are created on first access and cannot be
+// renamed or deleted.
+const (
+	Watchlist = "watchlist"
+	Favorites = "favorites"
+)
+
+var DefaultLists = []string{Watchlist, Favorites}
+
+// MaxEntries bounds the movies of a single list.
+const MaxEntries = 1000
+
+// List is a named, ordered list of movies of a user.
+type List struct {
+	ID        int       `json:"ID"`
+	Owner     string    `json:"-"`
+	Name      string    `json:"Name"`
+	Default   bool      `json:"Default"`
+	Entries   int       `json:"Entries"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+func (l List) Validate() error {
+	if l.Name == "" || len(l.Name) > 100 {
+		return errors.New("list name must be between 1 and 100 characters")
+	}
+	if l.Name != strings.TrimSpace(l.Name) {
+		return errors.New("list name must not start or end with spaces")
+	}
+	return nil
+}
+
+// Entry is a movie on a list. Positions are contiguous from zero.
+type Entry struct {
+	MovieID   int        `json:"MovieID"`
+	Position  int        `json:"Position"`
+	Note      string     `json:"Note,omitempty"`
+	AddedAt   time.Time  `json:"AddedAt"`
+	WatchedAt *time.Time `json:"WatchedAt"`
+}
+
+func (e Entry) Validate() error {
+	if len(e.Note) > 1000 {
+		return errors.New("note must be at most 1000 characters")
+	}
+	return nil
+}
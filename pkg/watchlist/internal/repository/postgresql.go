@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/lib/pq"
+)
+
+// PostgreSQL stores the lists of the users and their entries. Entry positions are kept
+// contiguous by shifting the neighbours inside the transaction moving an entry.
+type PostgreSQL struct {
+	db *sql.DB
+}
+
+func NewPostgreSQL(db *sql.DB) *PostgreSQL {
+	return &PostgreSQL{db: db}
+}
+
+const listColumns = "w.id, w.owner, w.name, w.is_default, w.created_at, w.updated_at, " +
+	"(SELECT count(*) FROM watchlist_entry AS e WHERE e.watchlist_id = w.id)"
+
+const entryColumns = "movie_id, position, note, added_at, watched_at"
+
+// EnsureDefaults creates the default lists the owner does not have yet.
+func (r *PostgreSQL) EnsureDefaults(ctx context.Context, owner string) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO watchlist (owner, name, is_default) SELECT $1, unnest($2::text[]), true "+
+		"ON CONFLICT (owner, name) DO NOTHING", owner, pq.Array(domain.DefaultLists))
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+	return nil
+}
+
+// Lists returns the lists of the owner, default lists first.
+func (r *PostgreSQL) Lists(ctx context.Context, owner string) ([]domain.List, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+listColumns+" FROM watchlist AS w WHERE w.owner = $1 "+
+		"ORDER BY w.is_default DESC, w.name", owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgresql query")
+	}
+	defer rows.Close()
+
+	lists := []domain.List{}
+
+	for rows.Next() {
+		l, err := scanList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, *l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return lists, nil
+}
+
+// Get returns the list if it belongs to the owner. Lists of other users are not found.
+func (r *PostgreSQL) Get(ctx context.Context, owner string, id int) (*domain.List, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+listColumns+" FROM watchlist AS w WHERE w.id = $1 AND w.owner = $2", id, owner)
+	return scanList(row)
+}
+
+func (r *PostgreSQL) Insert(ctx context.Context, l *domain.List) error {
+	err := r.db.QueryRowContext(ctx, "INSERT INTO watchlist (owner, name) VALUES ($1, $2) RETURNING id, created_at, updated_at",
+		l.Owner, l.Name).Scan(&l.ID, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+	return nil
+}
+
+func (r *PostgreSQL) Rename(ctx context.Context, owner string, id int, name string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE watchlist SET name = $3, updated_at = now() WHERE id = $1 AND owner = $2", id, owner, name)
+	return affected(res, err)
+}
+
+func (r *PostgreSQL) Delete(ctx context.Context, owner string, id int) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM watchlist WHERE id = $1 AND owner = $2", id, owner)
+	return affected(res, err)
+}
+
+// CountEntries counts the entries of the list, only the watched or unwatched ones when watched is set.
+func (r *PostgreSQL) CountEntries(ctx context.Context, listID int, watched *bool) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT count(*) FROM watchlist_entry WHERE watchlist_id = $1"+watchedClause(watched), listID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgresql query")
+	}
+	return count, nil
+}
+
+// Entries returns a page of the entries of the list in list order.
+func (r *PostgreSQL) Entries(ctx context.Context, listID int, watched *bool, offset, limit int) ([]domain.Entry, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+entryColumns+" FROM watchlist_entry WHERE watchlist_id = $1"+watchedClause(watched)+
+		" ORDER BY position OFFSET $2 LIMIT $3", listID, offset, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgresql query")
+	}
+	defer rows.Close()
+
+	entries := []domain.Entry{}
+
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+
+	return entries, nil
+}
+
+// PutEntry adds the movie to the list or updates its entry. The entry moves to the position
+// when one is given, new entries go last otherwise. The note is only changed when given.
+// It reports whether the entry was created.
+func (r *PostgreSQL) PutEntry(ctx context.Context, listID, movieID int, position *int, note *string) (*domain.Entry, bool, error) {
+	var (
+		entry   *domain.Entry
+		created bool
+	)
+
+	err := r.tx(ctx, listID, func(tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM watchlist_entry WHERE watchlist_id = $1", listID).Scan(&count); err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+
+		current, err := scanEntry(tx.QueryRowContext(ctx, "SELECT "+entryColumns+" FROM watchlist_entry "+
+			"WHERE watchlist_id = $1 AND movie_id = $2", listID, movieID))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if current == nil {
+			if count >= domain.MaxEntries {
+				return errors.NewConflictError("list holds the maximum of 1000 movies", "list is full")
+			}
+
+			target := clamp(position, count, count)
+			if _, err := tx.ExecContext(ctx, "UPDATE watchlist_entry SET position = position + 1 "+
+				"WHERE watchlist_id = $1 AND position >= $2", listID, target); err != nil {
+				return errors.Wrap(err, "postgresql query")
+			}
+
+			var text string
+			if note != nil {
+				text = *note
+			}
+
+			entry, err = scanEntry(tx.QueryRowContext(ctx, "INSERT INTO watchlist_entry (watchlist_id, movie_id, position, note) "+
+				"VALUES ($1, $2, $3, $4) RETURNING "+entryColumns, listID, movieID, target, text))
+			created = true
+			return err
+		}
+
+		target := clamp(position, current.Position, count-1)
+		switch {
+		case target < current.Position:
+			_, err = tx.ExecContext(ctx, "UPDATE watchlist_entry SET position = position + 1 "+
+				"WHERE watchlist_id = $1 AND position >= $2 AND position < $3", listID, target, current.Position)
+		case target > current.Position:
+			_, err = tx.ExecContext(ctx, "UPDATE watchlist_entry SET position = position - 1 "+
+				"WHERE watchlist_id = $1 AND position > $2 AND position <= $3", listID, current.Position, target)
+		}
+		if err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+
+		entry, err = scanEntry(tx.QueryRowContext(ctx, "UPDATE watchlist_entry SET position = $3, note = COALESCE($4, note) "+
+			"WHERE watchlist_id = $1 AND movie_id = $2 RETURNING "+entryColumns, listID, movieID, target, note))
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return entry, created, nil
+}
+
+// DeleteEntry removes the movie from the list and closes the gap it leaves.
+func (r *PostgreSQL) DeleteEntry(ctx context.Context, listID, movieID int) error {
+	return r.tx(ctx, listID, func(tx *sql.Tx) error {
+		var position int
+		err := tx.QueryRowContext(ctx, "DELETE FROM watchlist_entry WHERE watchlist_id = $1 AND movie_id = $2 RETURNING position",
+			listID, movieID).Scan(&position)
+		if err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE watchlist_entry SET position = position - 1 WHERE watchlist_id = $1 AND position > $2",
+			listID, position)
+		if err != nil {
+			return errors.Wrap(err, "postgresql query")
+		}
+		return nil
+	})
+}
+
+// SetWatched marks the entry watched at the given time, or unwatched when at is nil.
+func (r *PostgreSQL) SetWatched(ctx context.Context, listID, movieID int, at *time.Time) (*domain.Entry, error) {
+	var entry *domain.Entry
+
+	err := r.tx(ctx, listID, func(tx *sql.Tx) error {
+		var err error
+		entry, err = scanEntry(tx.QueryRowContext(ctx, "UPDATE watchlist_entry SET watched_at = $3 "+
+			"WHERE watchlist_id = $1 AND movie_id = $2 RETURNING "+entryColumns, listID, movieID, at))
+		return err
+	})
+	return entry, err
+}
+
+// tx runs fn in a transaction holding the lock of the list, which serializes the writes
+// reordering its entries, and touches the list after a successful write.
+func (r *PostgreSQL) tx(ctx context.Context, listID int, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "postgresql begin")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "SELECT id FROM watchlist WHERE id = $1 FOR UPDATE", listID)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE watchlist SET updated_at = now() WHERE id = $1", listID)
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "postgresql commit")
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanList(s scanner) (*domain.List, error) {
+	var l domain.List
+	if err := s.Scan(&l.ID, &l.Owner, &l.Name, &l.Default, &l.CreatedAt, &l.UpdatedAt, &l.Entries); err != nil {
+		return nil, errors.Wrap(err, "row scan")
+	}
+	return &l, nil
+}
+
+func scanEntry(s scanner) (*domain.Entry, error) {
+	var e domain.Entry
+	if err := s.Scan(&e.MovieID, &e.Position, &e.Note, &e.AddedAt, &e.WatchedAt); err != nil {
+		return nil, errors.Wrap(err, "row scan")
+	}
+	return &e, nil
+}
+
+func watchedClause(watched *bool) string {
+	switch {
+	case watched == nil:
+		return ""
+	case *watched:
+		return " AND watched_at IS NOT NULL"
+	}
+	return " AND watched_at IS NULL"
+}
+
+// clamp returns the requested position bounded by max, or def without a request.
+func clamp(position *int, def, max int) int {
+	if position == nil {
+		return def
+	}
+	if *position > max {
+		return max
+	}
+	return *position
+}
+
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return errors.Wrap(err, "postgresql query")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "postgresql rows affected")
+	}
+	if n == 0 {
+		return errors.Wrap(errors.ErrNotFound, "no such list")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+)
+
+type AddWatchlist struct {
+	Repo *repository.PostgreSQL
+}
+
+type AddWatchlistRequest struct {
+	Owner string
+	Name  string `json:"name"`
+}
+
+type AddWatchlistResponse struct {
+	List domain.List `json:"list"`
+}
+
+func NewAddWatchlist(repo *repository.PostgreSQL) *AddWatchlist {
+	return &AddWatchlist{Repo: repo}
+}
+
+func (m *AddWatchlist) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Add Watchlist",
+		Description: "Add a named list, names are unique per user",
+		Method:      http.MethodPost,
+		Path:        "/v1/watchlists",
+		Headers:     map[string]string{"Location": "path of the created list"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     AddWatchlistRequest{},
+		Response:    AddWatchlistResponse{},
+	}
+}
+
+func (m *AddWatchlist) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var req AddWatchlistRequest
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "watchlist body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+		req.Owner = principal.Email
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Location", "/v1/watchlists/"+strconv.Itoa(res.List.ID))
+		w.WriteHeader(http.StatusCreated)
+		return res, nil
+	}
+}
+
+func (m *AddWatchlist) handle(ctx context.Context, r AddWatchlistRequest) (*AddWatchlistResponse, error) {
+	list := domain.List{Owner: r.Owner, Name: r.Name}
+
+	err := list.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = m.Repo.EnsureDefaults(ctx, r.Owner)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	err = m.Repo.Insert(ctx, &list)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &AddWatchlistResponse{List: list}, nil
+}
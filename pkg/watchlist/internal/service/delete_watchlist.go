@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type DeleteWatchlist struct {
+	Repo *repository.PostgreSQL
+}
+
+type DeleteWatchlistRequest struct {
+	ListID int `path:"listId"`
+	Owner  string
+}
+
+type DeleteWatchlistResponse struct{}
+
+func NewDeleteWatchlist(repo *repository.PostgreSQL) *DeleteWatchlist {
+	return &DeleteWatchlist{Repo: repo}
+}
+
+func (m *DeleteWatchlist) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Watchlist",
+		Description: "Delete a list of the caller with its entries, the default lists cannot be deleted",
+		Method:      http.MethodDelete,
+		Path:        "/v1/watchlists/{listId}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteWatchlistRequest{},
+		Response:    DeleteWatchlistResponse{},
+	}
+}
+
+func (m *DeleteWatchlist) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		listID, err := parseListID(chi.URLParam(r, "listId"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, DeleteWatchlistRequest{ListID: listID, Owner: principal.Email})
+	}
+}
+
+func (m *DeleteWatchlist) handle(ctx context.Context, r DeleteWatchlistRequest) (*DeleteWatchlistResponse, error) {
+	list, err := getList(ctx, m.Repo, r.Owner, r.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Default {
+		return nil, errors.NewConflictError("default lists cannot be deleted", "delete of default list")
+	}
+
+	err = m.Repo.Delete(ctx, r.Owner, r.ListID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &DeleteWatchlistResponse{}, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type DeleteWatchlistEntry struct {
+	Repo *repository.PostgreSQL
+}
+
+type DeleteWatchlistEntryRequest struct {
+	ListID  int `path:"listId"`
+	MovieID int `path:"movieId"`
+	Owner   string
+}
+
+type DeleteWatchlistEntryResponse struct{}
+
+func NewDeleteWatchlistEntry(repo *repository.PostgreSQL) *DeleteWatchlistEntry {
+	return &DeleteWatchlistEntry{Repo: repo}
+}
+
+func (m *DeleteWatchlistEntry) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Delete Watchlist Entry",
+		Description: "Remove the movie from a list of the caller, the entries after it move up by one",
+		Method:      http.MethodDelete,
+		Path:        "/v1/watchlists/{listId}/entries/{movieId}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     DeleteWatchlistEntryRequest{},
+		Response:    DeleteWatchlistEntryResponse{},
+	}
+}
+
+func (m *DeleteWatchlistEntry) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		listID, err := parseListID(chi.URLParam(r, "listId"))
+		if err != nil {
+			return nil, err
+		}
+
+		movieID, err := parseMovieID(chi.URLParam(r, "movieId"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, DeleteWatchlistEntryRequest{ListID: listID, MovieID: movieID, Owner: principal.Email})
+	}
+}
+
+func (m *DeleteWatchlistEntry) handle(ctx context.Context, r DeleteWatchlistEntryRequest) (*DeleteWatchlistEntryResponse, error) {
+	_, err := getList(ctx, m.Repo, r.Owner, r.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Repo.DeleteEntry(ctx, r.ListID, r.MovieID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &DeleteWatchlistEntryResponse{}, nil
+}
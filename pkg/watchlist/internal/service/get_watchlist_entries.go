@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/internal/pagination"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type GetWatchlistEntries struct {
+	Repo   *repository.PostgreSQL
+	Movies catalog.Movies
+}
+
+type GetWatchlistEntriesRequest struct {
+	ListID  int `path:"listId"`
+	Owner   string
+	Watched *bool `query:"watched" description:"only watched or only unwatched entries"`
+	pagination.Request
+}
+
+type GetWatchlistEntriesResponse struct {
+	List       domain.List      `json:"list"`
+	TotalCount int              `json:"totalCount"`
+	Entries    []HydratedEntry  `json:"entries"`
+	Pagination pagination.Model `json:"pagination"`
+}
+
+func NewGetWatchlistEntries(repo *repository.PostgreSQL, movies catalog.Movies) *GetWatchlistEntries {
+	return &GetWatchlistEntries{Repo: repo, Movies: movies}
+}
+
+func (m *GetWatchlistEntries) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Watchlist Entries",
+		Description: "Get the entries of a list of the caller in list order, with the summaries of their movies",
+		Method:      http.MethodGet,
+		Path:        "/v1/watchlists/{listId}/entries",
+		Headers:     map[string]string{"Link": "first, prev, next and last page links"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     GetWatchlistEntriesRequest{},
+		Response:    GetWatchlistEntriesResponse{},
+	}
+}
+
+func (m *GetWatchlistEntries) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		listID, err := parseListID(chi.URLParam(r, "listId"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		req := GetWatchlistEntriesRequest{ListID: listID, Owner: principal.Email}
+
+		if err := req.Parse(r.URL.Query()); err != nil {
+			return nil, err
+		}
+
+		if v := r.URL.Query().Get("watched"); v != "" {
+			watched, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, errors.NewBadRequestError("watched must be true or false", errors.Wrap(err, "watched conversion").Error())
+			}
+			req.Watched = &watched
+		}
+
+		res, err := m.handle(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Pagination.Write(w, r)
+		return res, nil
+	}
+}
+
+func (m *GetWatchlistEntries) handle(ctx context.Context, r GetWatchlistEntriesRequest) (*GetWatchlistEntriesResponse, error) {
+	list, err := getList(ctx, m.Repo, r.Owner, r.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := m.Repo.CountEntries(ctx, r.ListID, r.Watched)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	p := pagination.New(r.Request, total)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries, err := m.Repo.Entries(ctx, r.ListID, r.Watched, r.Offset(), r.Size)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	hydrated, err := hydrate(ctx, m.Movies, entries)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &GetWatchlistEntriesResponse{List: *list, TotalCount: total, Entries: hydrated, Pagination: p}, nil
+}
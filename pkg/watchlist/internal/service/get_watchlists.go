@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+)
+
+type GetWatchlists struct {
+	Repo *repository.PostgreSQL
+}
+
+type GetWatchlistsRequest struct {
+	Owner string
+}
+
+type GetWatchlistsResponse struct {
+	Lists []domain.List `json:"lists"`
+}
+
+func NewGetWatchlists(repo *repository.PostgreSQL) *GetWatchlists {
+	return &GetWatchlists{Repo: repo}
+}
+
+func (m *GetWatchlists) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Get Watchlists",
+		Description: "Get the lists of the caller with their entry counts, the watchlist and favorites first",
+		Method:      http.MethodGet,
+		Path:        "/v1/watchlists",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     GetWatchlistsRequest{},
+		Response:    GetWatchlistsResponse{},
+	}
+}
+
+func (m *GetWatchlists) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, GetWatchlistsRequest{Owner: principal.Email})
+	}
+}
+
+func (m *GetWatchlists) handle(ctx context.Context, r GetWatchlistsRequest) (*GetWatchlistsResponse, error) {
+	err := m.Repo.EnsureDefaults(ctx, r.Owner)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	lists, err := m.Repo.Lists(ctx, r.Owner)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &GetWatchlistsResponse{Lists: lists}, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type MarkEntryWatched struct {
+	Repo *repository.PostgreSQL
+}
+
+type MarkEntryWatchedRequest struct {
+	ListID    int `path:"listId"`
+	MovieID   int `path:"movieId"`
+	Owner     string
+	WatchedAt *time.Time `json:"watchedAt" description:"when the movie was watched, now when absent"`
+}
+
+type MarkEntryWatchedResponse struct {
+	Entry domain.Entry `json:"entry"`
+}
+
+func NewMarkEntryWatched(repo *repository.PostgreSQL) *MarkEntryWatched {
+	return &MarkEntryWatched{Repo: repo}
+}
+
+func (m *MarkEntryWatched) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Mark Entry Watched",
+		Description: "Mark the movie on a list of the caller as watched",
+		Method:      http.MethodPut,
+		Path:        "/v1/watchlists/{listId}/entries/{movieId}/watched",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     MarkEntryWatchedRequest{},
+		Response:    MarkEntryWatchedResponse{},
+	}
+}
+
+func (m *MarkEntryWatched) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		listID, err := parseListID(chi.URLParam(r, "listId"))
+		if err != nil {
+			return nil, err
+		}
+
+		movieID, err := parseMovieID(chi.URLParam(r, "movieId"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		req := MarkEntryWatchedRequest{ListID: listID, MovieID: movieID}
+
+		if len(body) > 0 {
+			err = json.Unmarshal(body, &req)
+			if err != nil {
+				return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "watched body unmarshal").Error())
+			}
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+		req.Owner = principal.Email
+
+		return m.handle(ctx, req)
+	}
+}
+
+func (m *MarkEntryWatched) handle(ctx context.Context, r MarkEntryWatchedRequest) (*MarkEntryWatchedResponse, error) {
+	now := time.Now().UTC()
+
+	watchedAt := now
+	if r.WatchedAt != nil {
+		if r.WatchedAt.After(now) {
+			return nil, errors.NewBadRequestError("watchedAt must not be in the future", "future watched date")
+		}
+		watchedAt = r.WatchedAt.UTC()
+	}
+
+	_, err := getList(ctx, m.Repo, r.Owner, r.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := m.Repo.SetWatched(ctx, r.ListID, r.MovieID, &watchedAt)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &MarkEntryWatchedResponse{Entry: *entry}, nil
+}
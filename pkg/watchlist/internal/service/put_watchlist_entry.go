@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type PutWatchlistEntry struct {
+	Repo   *repository.PostgreSQL
+	Movies catalog.Movies
+}
+
+// EntryInput changes an entry. Absent fields keep their value, new entries go last without a position.
+type EntryInput struct {
+	Position *int    `json:"position" description:"zero based position, entries from there on move down by one"`
+	Note     *string `json:"note"`
+}
+
+type PutWatchlistEntryRequest struct {
+	ListID  int `path:"listId"`
+	MovieID int `path:"movieId"`
+	Owner   string
+	Entry   EntryInput `json:"entry"`
+}
+
+type PutWatchlistEntryResponse struct {
+	Entry HydratedEntry `json:"entry"`
+
+	created bool
+}
+
+func NewPutWatchlistEntry(repo *repository.PostgreSQL, movies catalog.Movies) *PutWatchlistEntry {
+	return &PutWatchlistEntry{Repo: repo, Movies: movies}
+}
+
+func (m *PutWatchlistEntry) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Put Watchlist Entry",
+		Description: "Add the movie to a list of the caller, or move it and change its note",
+		Method:      http.MethodPut,
+		Path:        "/v1/watchlists/{listId}/entries/{movieId}",
+		Headers:     map[string]string{"Location": "path of the created entry"},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     PutWatchlistEntryRequest{},
+		Response:    PutWatchlistEntryResponse{},
+	}
+}
+
+func (m *PutWatchlistEntry) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		listID, err := parseListID(chi.URLParam(r, "listId"))
+		if err != nil {
+			return nil, err
+		}
+
+		movieID, err := parseMovieID(chi.URLParam(r, "movieId"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		var input EntryInput
+
+		if len(body) > 0 {
+			err = json.Unmarshal(body, &input)
+			if err != nil {
+				return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "entry body unmarshal").Error())
+			}
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		res, err := m.handle(ctx, PutWatchlistEntryRequest{ListID: listID, MovieID: movieID, Owner: principal.Email, Entry: input})
+		if err != nil {
+			return nil, err
+		}
+
+		if res.created {
+			w.Header().Set("Location", "/v1/watchlists/"+strconv.Itoa(listID)+"/entries/"+strconv.Itoa(movieID))
+			w.WriteHeader(http.StatusCreated)
+		}
+		return res, nil
+	}
+}
+
+func (m *PutWatchlistEntry) handle(ctx context.Context, r PutWatchlistEntryRequest) (*PutWatchlistEntryResponse, error) {
+	if r.Entry.Position != nil && *r.Entry.Position < 0 {
+		return nil, errors.NewBadRequestError("position must not be negative", "negative entry position")
+	}
+	if r.Entry.Note != nil {
+		if err := (domain.Entry{Note: *r.Entry.Note}).Validate(); err != nil {
+			return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+		}
+	}
+
+	_, err := getList(ctx, m.Repo, r.Owner, r.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := m.Movies.Summaries(ctx, []int{r.MovieID})
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "movie summaries"))
+	}
+
+	movie, ok := movies[r.MovieID]
+	if !ok {
+		return nil, errors.NewNotFoundError("movie not found", "movie "+strconv.Itoa(r.MovieID)+" is missing")
+	}
+
+	entry, created, err := m.Repo.PutEntry(ctx, r.ListID, r.MovieID, r.Entry.Position, r.Entry.Note)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	return &PutWatchlistEntryResponse{Entry: HydratedEntry{Entry: *entry, Movie: &movie}, created: created}, nil
+}
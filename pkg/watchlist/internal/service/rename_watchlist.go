@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type RenameWatchlist struct {
+	Repo *repository.PostgreSQL
+}
+
+type RenameWatchlistRequest struct {
+	ListID int `path:"listId"`
+	Owner  string
+	Name   string `json:"name"`
+}
+
+type RenameWatchlistResponse struct {
+	List domain.List `json:"list"`
+}
+
+func NewRenameWatchlist(repo *repository.PostgreSQL) *RenameWatchlist {
+	return &RenameWatchlist{Repo: repo}
+}
+
+func (m *RenameWatchlist) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Rename Watchlist",
+		Description: "Rename a list of the caller, the default lists cannot be renamed",
+		Method:      http.MethodPut,
+		Path:        "/v1/watchlists/{listId}",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     RenameWatchlistRequest{},
+		Response:    RenameWatchlistResponse{},
+	}
+}
+
+func (m *RenameWatchlist) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		listID, err := parseListID(chi.URLParam(r, "listId"))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", "read body")
+		}
+
+		req := RenameWatchlistRequest{ListID: listID}
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, errors.NewBadRequestError("unaccepted body", errors.Wrap(err, "watchlist body unmarshal").Error())
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+		req.Owner = principal.Email
+
+		return m.handle(ctx, req)
+	}
+}
+
+func (m *RenameWatchlist) handle(ctx context.Context, r RenameWatchlistRequest) (*RenameWatchlistResponse, error) {
+	list, err := getList(ctx, m.Repo, r.Owner, r.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Default {
+		return nil, errors.NewConflictError("default lists cannot be renamed", "rename of default list")
+	}
+
+	list.Name = r.Name
+
+	err = list.Validate()
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error(), errors.Wrap(err, "validation").Error())
+	}
+
+	err = m.Repo.Rename(ctx, r.Owner, r.ListID, r.Name)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	list, err = m.Repo.Get(ctx, r.Owner, r.ListID)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &RenameWatchlistResponse{List: *list}, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/3n0ugh/allotropes/framework/application"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/internal/middleware"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+	"github.com/go-chi/chi"
+)
+
+type UnmarkEntryWatched struct {
+	Repo *repository.PostgreSQL
+}
+
+type UnmarkEntryWatchedRequest struct {
+	ListID  int `path:"listId"`
+	MovieID int `path:"movieId"`
+	Owner   string
+}
+
+type UnmarkEntryWatchedResponse struct {
+	Entry domain.Entry `json:"entry"`
+}
+
+func NewUnmarkEntryWatched(repo *repository.PostgreSQL) *UnmarkEntryWatched {
+	return &UnmarkEntryWatched{Repo: repo}
+}
+
+func (m *UnmarkEntryWatched) Route(ctx context.Context) application.Route {
+	return application.Route{
+		Name:        "Unmark Entry Watched",
+		Description: "Mark the movie on a list of the caller as not watched",
+		Method:      http.MethodDelete,
+		Path:        "/v1/watchlists/{listId}/entries/{movieId}/watched",
+		Headers:     map[string]string{},
+		Middlewares: []func(http.Handler) http.Handler{middleware.Auth},
+		Handler:     m.endpoint(ctx),
+		Request:     UnmarkEntryWatchedRequest{},
+		Response:    UnmarkEntryWatchedResponse{},
+	}
+}
+
+func (m *UnmarkEntryWatched) endpoint(ctx context.Context) application.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		listID, err := parseListID(chi.URLParam(r, "listId"))
+		if err != nil {
+			return nil, err
+		}
+
+		movieID, err := parseMovieID(chi.URLParam(r, "movieId"))
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := middleware.Principal(r.Context())
+
+		return m.handle(ctx, UnmarkEntryWatchedRequest{ListID: listID, MovieID: movieID, Owner: principal.Email})
+	}
+}
+
+func (m *UnmarkEntryWatched) handle(ctx context.Context, r UnmarkEntryWatchedRequest) (*UnmarkEntryWatchedResponse, error) {
+	_, err := getList(ctx, m.Repo, r.Owner, r.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := m.Repo.SetWatched(ctx, r.ListID, r.MovieID, nil)
+	if err != nil {
+		return nil, errors.Translate(err)
+	}
+	return &UnmarkEntryWatchedResponse{Entry: *entry}, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/3n0ugh/allotropes/internal/catalog"
+	"github.com/3n0ugh/allotropes/internal/errors"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/domain"
+	"github.com/3n0ugh/allotropes/pkg/watchlist/internal/repository"
+)
+
+// HydratedEntry is an entry with the summary of its movie. The movie is null when it was
+// deleted since it was added.
+type HydratedEntry struct {
+	domain.Entry
+	Movie *catalog.Movie `json:"Movie"`
+}
+
+// hydrate looks up the movies of the entries with a single batched lookup.
+func hydrate(ctx context.Context, movies catalog.Movies, entries []domain.Entry) ([]HydratedEntry, error) {
+	ids := make([]int, len(entries))
+	for i, e := range entries {
+		ids[i] = e.MovieID
+	}
+
+	summaries, err := movies.Summaries(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "movie summaries")
+	}
+
+	hydrated := make([]HydratedEntry, len(entries))
+	for i, e := range entries {
+		hydrated[i] = HydratedEntry{Entry: e}
+		if m, ok := summaries[e.MovieID]; ok {
+			hydrated[i].Movie = &m
+		}
+	}
+	return hydrated, nil
+}
+
+// getList returns the list of the owner. The default lists are created on the way, so that
+// they are there before the first entry is added.
+func getList(ctx context.Context, repo *repository.PostgreSQL, owner string, id int) (*domain.List, error) {
+	if err := repo.EnsureDefaults(ctx, owner); err != nil {
+		return nil, errors.Translate(err)
+	}
+
+	list, err := repo.Get(ctx, owner, id)
+	if err != nil {
+		return nil, errors.Translate(errors.Wrap(err, "postgresql query"))
+	}
+	return list, nil
+}
+
+func parseListID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.NewBadRequestError("list id must be integer", errors.Wrap(err, "list id conversion").Error())
+	}
+	return id, nil
+}
+
+func parseMovieID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.NewBadRequestError("movie id must be integer", errors.Wrap(err, "movie id conversion").Error())
+	}
+	return id, nil
+}